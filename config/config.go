@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -18,6 +19,43 @@ type AppConfig struct {
 	DBPort     string
 	DBName     string
 	JWTSecret  string
+	QRSecret   string
+
+	// Auth tokens
+	AccessTokenTTL       time.Duration
+	RefreshTokenTTL      time.Duration
+	TokenCleanupInterval time.Duration
+
+	// Accounts
+	EmailVerificationTTL     time.Duration
+	PasswordResetTTL         time.Duration
+	RequireEmailVerification bool
+
+	// Mail
+	MailDriver   string
+	MailFrom     string
+	MailDir      string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// Seat holds
+	HoldTTL           time.Duration
+	HoldSweepInterval time.Duration
+	WaitlistOfferTTL  time.Duration
+
+	// Payments
+	AppBaseURL           string
+	PaymentGateway       string
+	OrderTTL             time.Duration
+	MockPaymentSecret    string
+	MockPaymentDelay     time.Duration
+	MockPaymentSlowDelay time.Duration
+
+	// Event lifecycle
+	LifecycleInterval     time.Duration
+	CancellationBatchSize int
 }
 
 var App AppConfig
@@ -35,11 +73,67 @@ func LoadConfig() AppConfig {
 		DBPort:     os.Getenv("DB_PORT"),
 		DBName:     os.Getenv("DB_NAME"),
 		JWTSecret:  os.Getenv("JWT_SECRET"),
+		QRSecret:   getEnv("QR_SECRET", os.Getenv("JWT_SECRET")),
+
+		AccessTokenTTL:       time.Duration(getEnvInt("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTokenTTL:      time.Duration(getEnvInt("REFRESH_TOKEN_TTL_HOURS", 720)) * time.Hour,
+		TokenCleanupInterval: time.Duration(getEnvInt("TOKEN_CLEANUP_INTERVAL_MINUTES", 60)) * time.Minute,
+
+		EmailVerificationTTL:     time.Duration(getEnvInt("EMAIL_VERIFICATION_TTL_HOURS", 48)) * time.Hour,
+		PasswordResetTTL:         time.Duration(getEnvInt("PASSWORD_RESET_TTL_MINUTES", 60)) * time.Minute,
+		RequireEmailVerification: getEnv("REQUIRE_EMAIL_VERIFICATION", "true") == "true",
+
+		MailDriver:   getEnv("MAIL_DRIVER", "file"),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@malaka-ticket.local"),
+		MailDir:      getEnv("MAIL_DIR", "storage/mail"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+
+		HoldTTL:           time.Duration(getEnvInt("HOLD_TTL_MINUTES", 10)) * time.Minute,
+		HoldSweepInterval: time.Duration(getEnvInt("HOLD_SWEEP_INTERVAL_SECONDS", 30)) * time.Second,
+		WaitlistOfferTTL:  time.Duration(getEnvInt("WAITLIST_OFFER_TTL_MINUTES", 30)) * time.Minute,
+
+		AppBaseURL:           getEnv("APP_BASE_URL", "http://localhost:8080"),
+		PaymentGateway:       getEnv("PAYMENT_GATEWAY", "mock"),
+		OrderTTL:             time.Duration(getEnvInt("ORDER_TTL_MINUTES", 15)) * time.Minute,
+		MockPaymentSecret:    getEnv("MOCK_PAYMENT_SECRET", os.Getenv("JWT_SECRET")),
+		MockPaymentDelay:     time.Duration(getEnvInt("MOCK_PAYMENT_DELAY_SECONDS", 2)) * time.Second,
+		MockPaymentSlowDelay: time.Duration(getEnvInt("MOCK_PAYMENT_SLOW_DELAY_SECONDS", 60)) * time.Second,
+
+		LifecycleInterval:     time.Duration(getEnvInt("EVENT_LIFECYCLE_INTERVAL_SECONDS", 60)) * time.Second,
+		CancellationBatchSize: getEnvInt("EVENT_CANCELLATION_BATCH_SIZE", 200),
 	}
 
 	return App
 }
 
+// getEnv reads a string from the environment, falling back to the given
+// default when the variable is unset.
+func getEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvInt reads a positive integer from the environment, falling back to
+// the given default when the variable is unset or invalid.
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Invalid value for %s, using default %d", key, fallback)
+		return fallback
+	}
+	return parsed
+}
+
 func ConnectDatabase(cfg AppConfig) *gorm.DB {
 	var db *gorm.DB
 	var err error
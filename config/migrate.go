@@ -1,6 +1,7 @@
 package config
 
 import (
+	"case_study_api/constants"
 	"case_study_api/entities"
 	"fmt"
 	"log"
@@ -15,12 +16,44 @@ func AutoMigrate(db *gorm.DB) error {
 		&entities.Ticket{},
 		&entities.Event{},
 		&entities.User{},
+		&entities.TicketHold{},
+		&entities.TicketTier{},
+		&entities.TicketCheckIn{},
+		&entities.WaitlistEntry{},
+		&entities.Order{},
+		&entities.Payment{},
+		&entities.RefundPolicyRule{},
+		&entities.Refund{},
+		&entities.PromoCode{},
+		&entities.PromoRedemption{},
+		&entities.EventStatusHistory{},
+		&entities.EventCancellationJob{},
+		&entities.Notification{},
+		&entities.Venue{},
+		&entities.VenueHall{},
+		&entities.SeatSection{},
+		&entities.Seat{},
+		&entities.EventSeries{},
+		&entities.EventSeriesException{},
+		&entities.Session{},
+		&entities.Speaker{},
+		&entities.SessionRegistration{},
+		&entities.RefreshToken{},
+		&entities.RevokedToken{},
+		&entities.UserToken{},
+		&entities.Setting{},
+		&entities.Role{},
+		&entities.Permission{},
 	)
 }
 
 func SeedData(db *gorm.DB) error {
 	log.Println("Starting database seeding...")
 
+	if err := seedRoles(db); err != nil {
+		return err
+	}
+
 	var count int64
 	db.Model(&entities.User{}).Where("role = ?", "admin").Count(&count)
 	if count > 0 {
@@ -33,11 +66,15 @@ func SeedData(db *gorm.DB) error {
 		return fmt.Errorf("failed to create admin user: %v", err)
 	}
 
+	// Seeded accounts are trusted and need no email verification
+	verifiedAt := time.Now()
+
 	admin := entities.User{
-		Name:     "Super Admin",
-		Email:    "admin@system.com",
-		Password: string(hashed),
-		Role:     "admin",
+		Name:            "Super Admin",
+		Email:           "admin@system.com",
+		Password:        string(hashed),
+		Role:            "admin",
+		EmailVerifiedAt: &verifiedAt,
 	}
 	if err := db.Create(&admin).Error; err != nil {
 		log.Printf("Failed to create admin user: %v", err)
@@ -46,6 +83,24 @@ func SeedData(db *gorm.DB) error {
 		log.Println("✅ Admin user seeded: admin@system.com / admin123")
 	}
 
+	gateHashed, err := bcrypt.GenerateFromPassword([]byte("gate123"), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to create gate staff user: %v", err)
+	}
+
+	gateStaff := entities.User{
+		Name:            "Gate Staff",
+		Email:           "gate@system.com",
+		Password:        string(gateHashed),
+		Role:            "gate_staff",
+		EmailVerifiedAt: &verifiedAt,
+	}
+	if err := db.Create(&gateStaff).Error; err != nil {
+		log.Printf("Failed to create gate staff user: %v", err)
+	} else {
+		log.Println("✅ Gate staff user seeded: gate@system.com / gate123")
+	}
+
 	// Seed Events
 	log.Println("Seeding events...")
 	var eventCount int64
@@ -209,13 +264,112 @@ func SeedData(db *gorm.DB) error {
 	return nil
 }
 
+// permissionDescriptions documents every permission of the application.
+var permissionDescriptions = map[string]string{
+	constants.PermissionEventsCreate:    "Create events",
+	constants.PermissionEventsUpdate:    "Edit any event, its tiers, agenda, seat map and refund policy",
+	constants.PermissionEventsUpdateOwn: "Edit events the user created",
+	constants.PermissionEventsDelete:    "Delete any event",
+	constants.PermissionEventsDeleteOwn: "Delete events the user created",
+	constants.PermissionEventsCancel:    "Cancel any event and refund its tickets",
+	constants.PermissionEventsCancelOwn: "Cancel events the user created",
+	constants.PermissionSeriesManage:    "Manage recurring event series",
+	constants.PermissionVenuesManage:    "Manage venues, halls and seat maps",
+	constants.PermissionPromoCodes:      "Manage promo codes",
+	constants.PermissionReportsRead:     "Read sales and system reports",
+	constants.PermissionTicketsRead:     "View any user's tickets",
+	constants.PermissionTicketsCheckIn:  "Check in tickets at the gate",
+	constants.PermissionOrdersRead:      "View any user's orders",
+	constants.PermissionUsersManage:     "Manage users, their roles and suspensions",
+	constants.PermissionRolesManage:     "Manage roles and their permissions",
+	constants.PermissionSettingsManage:  "Change application settings",
+}
+
+// seedRoles creates every permission and the system roles. Admins get all
+// permissions; other roles can be adjusted later through the API.
+func seedRoles(db *gorm.DB) error {
+	var permissions []entities.Permission
+	for _, name := range constants.ValidPermissions {
+		permissions = append(permissions, entities.Permission{
+			Name:        name,
+			Description: permissionDescriptions[name],
+		})
+	}
+	if err := db.Create(&permissions).Error; err != nil {
+		return fmt.Errorf("failed to seed permissions: %v", err)
+	}
+
+	byName := make(map[string]entities.Permission, len(permissions))
+	for _, permission := range permissions {
+		byName[permission.Name] = permission
+	}
+
+	roles := []entities.Role{
+		{
+			Name:        constants.UserRoleAdmin,
+			Description: "Full access to the application",
+			IsSystem:    true,
+			Permissions: permissions,
+		},
+		{
+			Name:        constants.UserRoleGateStaff,
+			Description: "Checks tickets in at the venue",
+			IsSystem:    true,
+			Permissions: []entities.Permission{byName[constants.PermissionTicketsCheckIn]},
+		},
+		{
+			Name:        constants.UserRoleUser,
+			Description: "Customer who browses events and books tickets",
+			IsSystem:    true,
+		},
+	}
+	for _, role := range roles {
+		if err := db.Create(&role).Error; err != nil {
+			return fmt.Errorf("failed to seed role %s: %v", role.Name, err)
+		}
+	}
+
+	log.Println("✅ Roles and permissions seeded")
+	return nil
+}
+
 func ResetDatabase(db *gorm.DB) error {
 	log.Println("Starting database reset...")
 
 	// Drop all tables
 	if err := db.Migrator().DropTable(
+		"role_permissions",
+		&entities.Permission{},
+		&entities.Role{},
+		&entities.Setting{},
+		&entities.UserToken{},
+		&entities.RevokedToken{},
+		&entities.RefreshToken{},
+		&entities.SessionRegistration{},
+		"session_speakers",
+		&entities.Speaker{},
+		&entities.Session{},
+		&entities.EventSeriesException{},
+		&entities.Seat{},
+		&entities.SeatSection{},
+		&entities.Notification{},
+		&entities.EventCancellationJob{},
+		&entities.EventStatusHistory{},
+		&entities.PromoRedemption{},
+		&entities.PromoCode{},
+		&entities.Refund{},
+		&entities.RefundPolicyRule{},
+		&entities.Payment{},
+		&entities.Order{},
+		&entities.WaitlistEntry{},
+		&entities.TicketCheckIn{},
+		&entities.TicketHold{},
+		&entities.TicketTier{},
 		&entities.Ticket{},
 		&entities.Event{},
+		&entities.EventSeries{},
+		&entities.VenueHall{},
+		&entities.Venue{},
 		&entities.User{},
 	); err != nil {
 		return fmt.Errorf("failed to drop tables: %v", err)
@@ -10,15 +10,109 @@ const (
 
 // Ticket Status Constants
 const (
+	TicketStatusPending   = "pending"
 	TicketStatusBooked    = "booked"
 	TicketStatusCancelled = "cancelled"
 	TicketStatusUsed      = "used"
+	TicketStatusNoShow    = "no_show"
+)
+
+// Ticket Hold Status Constants
+const (
+	HoldStatusActive    = "active"
+	HoldStatusConfirmed = "confirmed"
+	HoldStatusReleased  = "released"
+	HoldStatusExpired   = "expired"
+)
+
+// Seat Status Constants
+const (
+	SeatStatusAvailable = "available"
+	SeatStatusHeld      = "held"
+	SeatStatusBooked    = "booked"
+)
+
+// Waitlist Status Constants
+const (
+	WaitlistStatusWaiting   = "waiting"
+	WaitlistStatusOffered   = "offered"
+	WaitlistStatusFulfilled = "fulfilled"
+	WaitlistStatusExpired   = "expired"
+	WaitlistStatusDeclined  = "declined"
+	WaitlistStatusCancelled = "cancelled"
+)
+
+// Order and Payment Status Constants
+const (
+	PaymentStatusPending           = "pending"
+	PaymentStatusPaid              = "paid"
+	PaymentStatusFailed            = "failed"
+	PaymentStatusExpired           = "expired"
+	PaymentStatusRefunded          = "refunded"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+)
+
+// Refund Status Constants
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusProcessed = "processed"
+	RefundStatusFailed    = "failed"
+	RefundStatusDeclined  = "declined"
+)
+
+// Promo Code Constants
+const (
+	DiscountTypePercentage = "percentage"
+	DiscountTypeFixed      = "fixed"
+
+	RedemptionStatusRedeemed = "redeemed"
+	RedemptionStatusReleased = "released"
+)
+
+// Background Job Status Constants
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+)
+
+// Notification Constants
+const (
+	NotificationStatusQueued = "queued"
+	NotificationStatusSent   = "sent"
+	NotificationStatusFailed = "failed"
+
+	NotificationTypeEventCancelled = "event_cancelled"
 )
 
 // User Role Constants
 const (
-	UserRoleUser  = "user"
-	UserRoleAdmin = "admin"
+	UserRoleUser      = "user"
+	UserRoleAdmin     = "admin"
+	UserRoleGateStaff = "gate_staff"
+)
+
+// User Account Status Constants
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+)
+
+// User Token Purpose Constants
+const (
+	TokenPurposeVerifyEmail   = "verify_email"
+	TokenPurposeResetPassword = "reset_password"
+)
+
+// Setting Key Constants
+const (
+	SettingRequireEmailVerification = "require_email_verification"
+)
+
+// Event Series Edit Scope Constants
+const (
+	SeriesScopeThis   = "this"
+	SeriesScopeFuture = "future"
 )
 
 // Sort Order Constants
@@ -64,15 +158,11 @@ var ValidEventStatuses = []string{
 
 // Valid Ticket Statuses
 var ValidTicketStatuses = []string{
+	TicketStatusPending,
 	TicketStatusBooked,
 	TicketStatusCancelled,
 	TicketStatusUsed,
-}
-
-// Valid User Roles
-var ValidUserRoles = []string{
-	UserRoleUser,
-	UserRoleAdmin,
+	TicketStatusNoShow,
 }
 
 // Helper functions to validate enum values
@@ -94,15 +184,6 @@ func IsValidTicketStatus(status string) bool {
 	return false
 }
 
-func IsValidUserRole(role string) bool {
-	for _, validRole := range ValidUserRoles {
-		if role == validRole {
-			return true
-		}
-	}
-	return false
-}
-
 func IsValidEventCategory(category string) bool {
 	for _, validCategory := range ValidEventCategories {
 		if category == validCategory {
@@ -0,0 +1,47 @@
+package constants
+
+// Permission Constants. A permission ending in ":own" grants the action
+// only on resources the user created.
+const (
+	PermissionEventsCreate    = "events:create"
+	PermissionEventsUpdate    = "events:update"
+	PermissionEventsUpdateOwn = "events:update:own"
+	PermissionEventsDelete    = "events:delete"
+	PermissionEventsDeleteOwn = "events:delete:own"
+	PermissionEventsCancel    = "events:cancel"
+	PermissionEventsCancelOwn = "events:cancel:own"
+	PermissionSeriesManage    = "series:manage"
+	PermissionVenuesManage    = "venues:manage"
+	PermissionPromoCodes      = "promo_codes:manage"
+	PermissionReportsRead     = "reports:read"
+	PermissionTicketsRead     = "tickets:read"
+	PermissionTicketsCheckIn  = "tickets:checkin"
+	PermissionOrdersRead      = "orders:read"
+	PermissionUsersManage     = "users:manage"
+	PermissionRolesManage     = "roles:manage"
+	PermissionSettingsManage  = "settings:manage"
+)
+
+// OwnPermissionSuffix turns a permission into its owner-scoped variant
+const OwnPermissionSuffix = ":own"
+
+// Valid Permissions
+var ValidPermissions = []string{
+	PermissionEventsCreate,
+	PermissionEventsUpdate,
+	PermissionEventsUpdateOwn,
+	PermissionEventsDelete,
+	PermissionEventsDeleteOwn,
+	PermissionEventsCancel,
+	PermissionEventsCancelOwn,
+	PermissionSeriesManage,
+	PermissionVenuesManage,
+	PermissionPromoCodes,
+	PermissionReportsRead,
+	PermissionTicketsRead,
+	PermissionTicketsCheckIn,
+	PermissionOrdersRead,
+	PermissionUsersManage,
+	PermissionRolesManage,
+	PermissionSettingsManage,
+}
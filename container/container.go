@@ -1,8 +1,13 @@
 package container
 
 import (
+	"case_study_api/config"
+	"case_study_api/mailer"
+	"case_study_api/payments"
 	"case_study_api/repositories"
 	"case_study_api/services"
+	"case_study_api/utils"
+	"log"
 
 	"gorm.io/gorm"
 )
@@ -11,40 +16,176 @@ type Container struct {
 	DB *gorm.DB
 
 	// Repositories
-	UserRepo   repositories.UserRepository
-	EventRepo  repositories.EventRepository
-	TicketRepo repositories.TicketRepository
-	ReportRepo repositories.ReportRepository
+	TxManager        repositories.TxManager
+	UserRepo         repositories.UserRepository
+	EventRepo        repositories.EventRepository
+	TicketRepo       repositories.TicketRepository
+	ReportRepo       repositories.ReportRepository
+	HoldRepo         repositories.TicketHoldRepository
+	TierRepo         repositories.TicketTierRepository
+	CheckInRepo      repositories.TicketCheckInRepository
+	WaitlistRepo     repositories.WaitlistRepository
+	OrderRepo        repositories.OrderRepository
+	PaymentRepo      repositories.PaymentRepository
+	RefundRepo       repositories.RefundRepository
+	PolicyRepo       repositories.RefundPolicyRepository
+	PromoRepo        repositories.PromoCodeRepository
+	HistoryRepo      repositories.EventStatusHistoryRepository
+	CancellationRepo repositories.EventCancellationRepository
+	NotificationRepo repositories.NotificationRepository
+	VenueRepo        repositories.VenueRepository
+	SeatRepo         repositories.SeatRepository
+	SeriesRepo       repositories.EventSeriesRepository
+	SessionRepo      repositories.SessionRepository
+	SpeakerRepo      repositories.SpeakerRepository
+	RefreshRepo      repositories.RefreshTokenRepository
+	RevokedRepo      repositories.RevokedTokenRepository
+	UserTokenRepo    repositories.UserTokenRepository
+	SettingRepo      repositories.SettingRepository
+	RoleRepo         repositories.RoleRepository
+	PermissionRepo   repositories.PermissionRepository
 
 	// Services
-	AuthService   services.AuthService
-	EventService  services.EventService
-	TicketService services.TicketService
-	ReportService services.ReportService
+	AuthService         services.AuthService
+	EventService        services.EventService
+	TicketService       services.TicketService
+	ReportService       services.ReportService
+	TierService         services.TicketTierService
+	CheckInService      services.CheckInService
+	WaitlistService     services.WaitlistService
+	PaymentService      services.PaymentService
+	RefundPolicyService services.RefundPolicyService
+	PromoCodeService    services.PromoCodeService
+	LifecycleService    services.EventLifecycleService
+	CancellationService services.EventCancellationService
+	VenueService        services.VenueService
+	SeatMapService      services.SeatMapService
+	SeriesService       services.EventSeriesService
+	AgendaService       services.AgendaService
+	CalendarService     services.CalendarService
+	SettingService      services.SettingService
+	ProfileService      services.ProfileService
+	UserService         services.UserService
+	RoleService         services.RoleService
 }
 
 func NewContainer(db *gorm.DB) *Container {
 	// Initialize repositories
+	txManager := repositories.NewTxManager(db)
 	userRepo := repositories.NewUserRepository(db)
 	eventRepo := repositories.NewEventRepository(db)
 	ticketRepo := repositories.NewTicketRepository(db)
 	reportRepo := repositories.NewReportRepository(db)
+	holdRepo := repositories.NewTicketHoldRepository(db)
+	tierRepo := repositories.NewTicketTierRepository(db)
+	checkInRepo := repositories.NewTicketCheckInRepository(db)
+	waitlistRepo := repositories.NewWaitlistRepository(db)
+	orderRepo := repositories.NewOrderRepository(db)
+	paymentRepo := repositories.NewPaymentRepository(db)
+	refundRepo := repositories.NewRefundRepository(db)
+	policyRepo := repositories.NewRefundPolicyRepository(db)
+	promoRepo := repositories.NewPromoCodeRepository(db)
+	historyRepo := repositories.NewEventStatusHistoryRepository(db)
+	cancellationRepo := repositories.NewEventCancellationRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	venueRepo := repositories.NewVenueRepository(db)
+	seatRepo := repositories.NewSeatRepository(db)
+	seriesRepo := repositories.NewEventSeriesRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	speakerRepo := repositories.NewSpeakerRepository(db)
+	refreshRepo := repositories.NewRefreshTokenRepository(db)
+	revokedRepo := repositories.NewRevokedTokenRepository(db)
+	userTokenRepo := repositories.NewUserTokenRepository(db)
+	settingRepo := repositories.NewSettingRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
+	permissionRepo := repositories.NewPermissionRepository(db)
+
+	// Payment gateways
+	gateway := newPaymentGateway()
 
 	// Initialize services with dependency injection
-	authService := services.NewAuthService(userRepo)
-	eventService := services.NewEventService(eventRepo)
-	ticketService := services.NewTicketService(ticketRepo, eventRepo)
+	settingService := services.NewSettingService(settingRepo, config.App.RequireEmailVerification)
+	authService := services.NewAuthService(txManager, userRepo, refreshRepo, revokedRepo, userTokenRepo, settingService, newMailer(), config.App.AccessTokenTTL, config.App.RefreshTokenTTL, config.App.EmailVerificationTTL, config.App.PasswordResetTTL, config.App.AppBaseURL)
+	profileService := services.NewProfileService(txManager, userRepo, refreshRepo, revokedRepo, userTokenRepo, roleRepo, authService)
+	eventService := services.NewEventService(txManager, eventRepo, historyRepo, venueRepo, tierRepo)
+	ticketService := services.NewTicketService(txManager, ticketRepo, eventRepo, holdRepo, tierRepo, waitlistRepo, orderRepo, paymentRepo, refundRepo, policyRepo, promoRepo, seatRepo, sessionRepo, gateway, config.App.HoldTTL, config.App.WaitlistOfferTTL, config.App.OrderTTL)
 	reportService := services.NewReportService(reportRepo)
+	userService := services.NewUserService(txManager, userRepo, refreshRepo, roleRepo, reportRepo, ticketService)
+	roleService := services.NewRoleService(txManager, roleRepo, permissionRepo)
+	tierService := services.NewTicketTierService(txManager, tierRepo, eventRepo)
+	checkInService := services.NewCheckInService(txManager, ticketRepo, eventRepo, checkInRepo)
+	waitlistService := services.NewWaitlistService(waitlistRepo, eventRepo, tierRepo)
+	paymentService := services.NewPaymentService(orderRepo, paymentRepo, ticketService, gateway)
+	refundPolicyService := services.NewRefundPolicyService(policyRepo, eventRepo)
+	promoCodeService := services.NewPromoCodeService(promoRepo, eventRepo)
+	venueService := services.NewVenueService(txManager, venueRepo)
+	seatMapService := services.NewSeatMapService(txManager, seatRepo, eventRepo, venueRepo)
+	seriesService := services.NewEventSeriesService(txManager, seriesRepo, eventRepo, venueRepo)
+	agendaService := services.NewAgendaService(txManager, sessionRepo, speakerRepo, eventRepo, ticketRepo)
+	calendarService := services.NewCalendarService(userRepo, eventRepo, ticketRepo, config.App.AppBaseURL)
+	cancellationService := services.NewEventCancellationService(txManager, eventRepo, historyRepo, ticketRepo, tierRepo, orderRepo, paymentRepo, refundRepo, waitlistRepo, sessionRepo, seatRepo, promoRepo, cancellationRepo, notificationRepo, ticketService, config.App.CancellationBatchSize)
+	lifecycleService := services.NewEventLifecycleService(txManager, eventRepo, ticketRepo, historyRepo, utils.SystemClock{})
 
 	return &Container{
-		DB:            db,
-		UserRepo:      userRepo,
-		EventRepo:     eventRepo,
-		TicketRepo:    ticketRepo,
-		ReportRepo:    reportRepo,
-		AuthService:   authService,
-		EventService:  eventService,
-		TicketService: ticketService,
-		ReportService: reportService,
+		DB:                  db,
+		TxManager:           txManager,
+		UserRepo:            userRepo,
+		EventRepo:           eventRepo,
+		TicketRepo:          ticketRepo,
+		ReportRepo:          reportRepo,
+		HoldRepo:            holdRepo,
+		TierRepo:            tierRepo,
+		CheckInRepo:         checkInRepo,
+		WaitlistRepo:        waitlistRepo,
+		OrderRepo:           orderRepo,
+		PaymentRepo:         paymentRepo,
+		RefundRepo:          refundRepo,
+		PolicyRepo:          policyRepo,
+		AuthService:         authService,
+		EventService:        eventService,
+		TicketService:       ticketService,
+		ReportService:       reportService,
+		TierService:         tierService,
+		CheckInService:      checkInService,
+		WaitlistService:     waitlistService,
+		PaymentService:      paymentService,
+		RefundPolicyService: refundPolicyService,
+		PromoCodeService:    promoCodeService,
+		LifecycleService:    lifecycleService,
+		CancellationService: cancellationService,
+		VenueService:        venueService,
+		SeatMapService:      seatMapService,
+		SeriesService:       seriesService,
+		AgendaService:       agendaService,
+		CalendarService:     calendarService,
+		SettingService:      settingService,
+		ProfileService:      profileService,
+		UserService:         userService,
+		RoleService:         roleService,
+	}
+}
+
+// newPaymentGateway returns the gateway selected by PAYMENT_GATEWAY. An
+// unknown gateway stops the application at startup rather than at the first
+// payment.
+func newPaymentGateway() payments.Gateway {
+	switch config.App.PaymentGateway {
+	case payments.GatewayMock:
+		return payments.NewMockGateway(config.App.MockPaymentSecret, config.App.AppBaseURL+"/payments/callback/"+payments.GatewayMock, config.App.MockPaymentDelay, config.App.MockPaymentSlowDelay)
+	default:
+		log.Fatalf("❌ Unknown PAYMENT_GATEWAY %q", config.App.PaymentGateway)
+		return nil
+	}
+}
+
+// newMailer returns the mailer selected by MAIL_DRIVER.
+func newMailer() mailer.Mailer {
+	switch config.App.MailDriver {
+	case mailer.DriverSMTP:
+		return mailer.NewSMTPMailer(config.App.SMTPHost, config.App.SMTPPort, config.App.SMTPUsername, config.App.SMTPPassword, config.App.MailFrom)
+	case mailer.DriverLog:
+		return mailer.NewLogMailer()
+	default:
+		return mailer.NewFileMailer(config.App.MailDir, config.App.MailFrom)
 	}
 }
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AgendaController struct {
+	agendaService services.AgendaService
+}
+
+func NewAgendaController(agendaService services.AgendaService) *AgendaController {
+	return &AgendaController{
+		agendaService: agendaService,
+	}
+}
+
+// GetAgenda serves the public programme of an event.
+func (ac *AgendaController) GetAgenda(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	agenda, err := ac.agendaService.GetAgenda(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", agenda))
+}
+
+func (ac *AgendaController) GetSessions(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	sessions, err := ac.agendaService.GetSessions(uint(eventID), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", sessions))
+}
+
+func (ac *AgendaController) CreateSession(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	var req dto.CreateSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	session, err := ac.agendaService.CreateSession(uint(eventID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("session created", session))
+}
+
+func (ac *AgendaController) UpdateSession(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("session_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid session id"))
+		return
+	}
+
+	var req dto.UpdateSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	session, err := ac.agendaService.UpdateSession(uint(eventID), uint(sessionID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("session updated", session))
+}
+
+func (ac *AgendaController) DeleteSession(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("session_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid session id"))
+		return
+	}
+
+	if err := ac.agendaService.DeleteSession(uint(eventID), uint(sessionID)); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("session deleted", nil))
+}
+
+func (ac *AgendaController) RegisterSession(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("session_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid session id"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	session, err := ac.agendaService.Register(uint(eventID), uint(sessionID), userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("registered for session", session))
+}
+
+func (ac *AgendaController) UnregisterSession(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("session_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid session id"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	if err := ac.agendaService.Unregister(uint(eventID), uint(sessionID), userID); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("registration withdrawn", nil))
+}
+
+func (ac *AgendaController) GetSpeakers(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	speakers, err := ac.agendaService.GetSpeakers(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", speakers))
+}
+
+func (ac *AgendaController) CreateSpeaker(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	var req dto.CreateSpeakerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	speaker, err := ac.agendaService.CreateSpeaker(uint(eventID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("speaker created", speaker))
+}
+
+func (ac *AgendaController) UpdateSpeaker(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	speakerID, err := strconv.Atoi(c.Param("speaker_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid speaker id"))
+		return
+	}
+
+	var req dto.UpdateSpeakerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	speaker, err := ac.agendaService.UpdateSpeaker(uint(eventID), uint(speakerID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("speaker updated", speaker))
+}
+
+func (ac *AgendaController) DeleteSpeaker(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	speakerID, err := strconv.Atoi(c.Param("speaker_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid speaker id"))
+		return
+	}
+
+	if err := ac.agendaService.DeleteSpeaker(uint(eventID), uint(speakerID)); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("speaker deleted", nil))
+}
@@ -5,6 +5,7 @@ import (
 	"case_study_api/services"
 	"case_study_api/utils"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -50,3 +51,95 @@ func (ac *AuthController) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, utils.BuildSuccessResponse("login successful", res))
 }
+
+func (ac *AuthController) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	res, err := ac.authService.Refresh(req)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("token refreshed", res))
+}
+
+func (ac *AuthController) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	// The body is optional; without it only the access token is revoked
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+			return
+		}
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	tokenID := c.MustGet("token_id").(string)
+	expiresAt := c.MustGet("token_expires_at").(time.Time)
+	if err := ac.authService.Logout(userID, tokenID, expiresAt, req); err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to log out"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("logout successful", nil))
+}
+
+func (ac *AuthController) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("token is required"))
+		return
+	}
+
+	if err := ac.authService.VerifyEmail(token); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("email verified", nil))
+}
+
+func (ac *AuthController) ResendVerification(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+	if err := ac.authService.ResendVerification(userID); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("verification email sent", nil))
+}
+
+func (ac *AuthController) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	if err := ac.authService.ForgotPassword(req); err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to process request"))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("if the email is registered, a reset token has been sent", nil))
+}
+
+func (ac *AuthController) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	if err := ac.authService.ResetPassword(req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("password has been reset", nil))
+}
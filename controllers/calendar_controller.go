@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"case_study_api/services"
+	"case_study_api/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarController struct {
+	calendarService services.CalendarService
+}
+
+func NewCalendarController(calendarService services.CalendarService) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+func (cc *CalendarController) DownloadEventICal(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	calendar, err := cc.calendarService.GetEventCalendar(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=event-%d.ics", id))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+// GetFeed serves the personal calendar feed. It is authenticated by the
+// token in the URL only, so calendar apps can subscribe to it.
+func (cc *CalendarController) GetFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := cc.calendarService.GetFeed(token)
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+func (cc *CalendarController) CreateFeedToken(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+
+	feed, err := cc.calendarService.CreateFeedToken(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to create calendar feed"))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("calendar feed created", feed))
+}
+
+func (cc *CalendarController) RevokeFeedToken(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+
+	if err := cc.calendarService.RevokeFeedToken(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to revoke calendar feed"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("calendar feed revoked", nil))
+}
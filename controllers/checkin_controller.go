@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CheckInController struct {
+	checkInService services.CheckInService
+}
+
+func NewCheckInController(checkInService services.CheckInService) *CheckInController {
+	return &CheckInController{
+		checkInService: checkInService,
+	}
+}
+
+func (cc *CheckInController) CheckIn(c *gin.Context) {
+	var req dto.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	scannerID := c.MustGet("user_id").(uint)
+	result, err := cc.checkInService.CheckIn(req, scannerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("ticket checked in", result))
+}
+
+func (cc *CheckInController) VerifyQRPayload(c *gin.Context) {
+	var req dto.VerifyTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	result, err := cc.checkInService.VerifyQRPayload(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("ticket signature valid", result))
+}
@@ -11,21 +11,39 @@ import (
 )
 
 type EventController struct {
-	eventService services.EventService
+	eventService        services.EventService
+	cancellationService services.EventCancellationService
 }
 
-func NewEventController(eventService services.EventService) *EventController {
+func NewEventController(eventService services.EventService, cancellationService services.EventCancellationService) *EventController {
 	return &EventController{
-		eventService: eventService,
+		eventService:        eventService,
+		cancellationService: cancellationService,
 	}
 }
 
 func (ec *EventController) GetEventsPaginated(c *gin.Context) {
 	pagination := utils.GetPaginationFromQuery(c)
 
-	result, err := ec.eventService.GetAllPaginated(pagination)
+	var query dto.EventListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid query parameters"))
+		return
+	}
+
+	if pagination.UseCursor {
+		result, err := ec.eventService.GetAllByCursor(query, pagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", result))
+		return
+	}
+
+	result, err := ec.eventService.GetAllPaginated(query, pagination)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch events"))
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
 		return
 	}
 
@@ -77,7 +95,8 @@ func (ec *EventController) UpdateEvent(c *gin.Context) {
 		return
 	}
 
-	updated, err := ec.eventService.Update(uint(id), req)
+	userID := c.MustGet("user_id").(uint)
+	updated, err := ec.eventService.Update(uint(id), req, userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
 		return
@@ -98,3 +117,77 @@ func (ec *EventController) DeleteEvent(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, utils.BuildSuccessResponse("event deleted", nil))
 }
+
+func (ec *EventController) UpdateEventStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	var req dto.UpdateEventStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	updated, err := ec.eventService.UpdateStatus(uint(id), req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("event status updated", updated))
+}
+
+func (ec *EventController) GetEventHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	history, err := ec.eventService.GetStatusHistory(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", history))
+}
+
+func (ec *EventController) CancelEvent(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	var req dto.CancelEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	job, err := ec.cancellationService.CancelEvent(uint(id), req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusAccepted, utils.BuildSuccessResponse("event cancellation started", job))
+}
+
+func (ec *EventController) GetCancellationStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	job, err := ec.cancellationService.GetStatus(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", job))
+}
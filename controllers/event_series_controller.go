@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EventSeriesController struct {
+	seriesService services.EventSeriesService
+}
+
+func NewEventSeriesController(seriesService services.EventSeriesService) *EventSeriesController {
+	return &EventSeriesController{
+		seriesService: seriesService,
+	}
+}
+
+func (sc *EventSeriesController) GetSeriesList(c *gin.Context) {
+	series, err := sc.seriesService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch event series"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", series))
+}
+
+func (sc *EventSeriesController) GetSeries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	series, err := sc.seriesService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", series))
+}
+
+func (sc *EventSeriesController) CreateSeries(c *gin.Context) {
+	var req dto.CreateEventSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	creator := c.MustGet("user_id").(uint)
+	series, err := sc.seriesService.Create(req, creator)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("event series created", series))
+}
+
+func (sc *EventSeriesController) UpdateOccurrence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("event_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	var req dto.UpdateSeriesOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	series, err := sc.seriesService.UpdateOccurrence(uint(id), uint(eventID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("occurrence updated", series))
+}
+
+func (sc *EventSeriesController) AddException(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	var req dto.EventSeriesExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	series, err := sc.seriesService.AddException(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("exception added", series))
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"case_study_api/constants"
+	"case_study_api/middleware"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentController struct {
+	paymentService services.PaymentService
+}
+
+func NewPaymentController(paymentService services.PaymentService) *PaymentController {
+	return &PaymentController{
+		paymentService: paymentService,
+	}
+}
+
+// HandleCallback receives webhooks from payment gateways. It is mounted
+// outside the JWT group; authenticity comes from the gateway signature.
+func (pc *PaymentController) HandleCallback(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	if err := pc.paymentService.HandleCallback(c.Param("gateway"), payload, c.Request.Header); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("callback processed", nil))
+}
+
+func (pc *PaymentController) GetMyOrders(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+	pagination := utils.GetPaginationFromQuery(c)
+
+	result, err := pc.paymentService.GetUserOrders(userID, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch orders"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", result))
+}
+
+func (pc *PaymentController) GetOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	isAdmin := middleware.HasPermission(c, constants.PermissionOrdersRead)
+
+	order, err := pc.paymentService.GetOrder(uint(id), userID, isAdmin)
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", order))
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileController struct {
+	profileService services.ProfileService
+}
+
+func NewProfileController(profileService services.ProfileService) *ProfileController {
+	return &ProfileController{
+		profileService: profileService,
+	}
+}
+
+func (pc *ProfileController) GetProfile(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+	profile, err := pc.profileService.GetProfile(userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", profile))
+}
+
+func (pc *ProfileController) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	profile, err := pc.profileService.UpdateProfile(userID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("profile updated", profile))
+}
+
+func (pc *ProfileController) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	if err := pc.profileService.ChangePassword(userID, req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("password changed", nil))
+}
+
+func (pc *ProfileController) DeleteAccount(c *gin.Context) {
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	tokenID := c.MustGet("token_id").(string)
+	expiresAt := c.MustGet("token_expires_at").(time.Time)
+	if err := pc.profileService.DeleteAccount(userID, tokenID, expiresAt, req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("account deleted", nil))
+}
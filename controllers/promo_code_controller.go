@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromoCodeController struct {
+	promoService services.PromoCodeService
+}
+
+func NewPromoCodeController(promoService services.PromoCodeService) *PromoCodeController {
+	return &PromoCodeController{
+		promoService: promoService,
+	}
+}
+
+func (pc *PromoCodeController) GetPromoCodes(c *gin.Context) {
+	promos, err := pc.promoService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch promo codes"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", promos))
+}
+
+func (pc *PromoCodeController) GetPromoCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	promo, err := pc.promoService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", promo))
+}
+
+func (pc *PromoCodeController) CreatePromoCode(c *gin.Context) {
+	var req dto.CreatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	promo, err := pc.promoService.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("promo code created", promo))
+}
+
+func (pc *PromoCodeController) UpdatePromoCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	var req dto.UpdatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	promo, err := pc.promoService.Update(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("promo code updated", promo))
+}
+
+func (pc *PromoCodeController) DeletePromoCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	if err := pc.promoService.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("promo code deleted", nil))
+}
+
+func (pc *PromoCodeController) GetRedemptionReport(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	report, err := pc.promoService.GetRedemptionReport(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", report))
+}
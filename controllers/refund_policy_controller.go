@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RefundPolicyController struct {
+	policyService services.RefundPolicyService
+}
+
+func NewRefundPolicyController(policyService services.RefundPolicyService) *RefundPolicyController {
+	return &RefundPolicyController{
+		policyService: policyService,
+	}
+}
+
+func (rc *RefundPolicyController) GetPolicy(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	policy, err := rc.policyService.GetPolicy(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", policy))
+}
+
+func (rc *RefundPolicyController) UpdatePolicy(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	var req dto.UpdateRefundPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	policy, err := rc.policyService.UpdatePolicy(uint(eventID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("refund policy updated", policy))
+}
@@ -10,12 +10,14 @@ import (
 )
 
 type ReportController struct {
-	reportService services.ReportService
+	reportService    services.ReportService
+	lifecycleService services.EventLifecycleService
 }
 
-func NewReportController(reportService services.ReportService) *ReportController {
+func NewReportController(reportService services.ReportService, lifecycleService services.EventLifecycleService) *ReportController {
 	return &ReportController{
-		reportService: reportService,
+		reportService:    reportService,
+		lifecycleService: lifecycleService,
 	}
 }
 
@@ -43,6 +45,21 @@ func (rc *ReportController) EventReport(c *gin.Context) {
 	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", report))
 }
 
+func (rc *ReportController) SeriesReport(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid series id"))
+		return
+	}
+
+	report, err := rc.reportService.GetSeriesReport(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", report))
+}
+
 func (rc *ReportController) SystemReport(c *gin.Context) {
 	report, err := rc.reportService.GetSystemReport()
 	if err != nil {
@@ -63,3 +80,7 @@ func (rc *ReportController) SystemReportPDF(c *gin.Context) {
 	c.Header("Content-Disposition", "attachment; filename=malaka_ticket_system_report.pdf")
 	c.Data(http.StatusOK, "application/pdf", pdfData)
 }
+
+func (rc *ReportController) LifecycleReport(c *gin.Context) {
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", rc.lifecycleService.GetStats()))
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleController struct {
+	roleService services.RoleService
+}
+
+func NewRoleController(roleService services.RoleService) *RoleController {
+	return &RoleController{
+		roleService: roleService,
+	}
+}
+
+func (rc *RoleController) GetRoles(c *gin.Context) {
+	roles, err := rc.roleService.GetRoles()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch roles"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", roles))
+}
+
+func (rc *RoleController) GetRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid role id"))
+		return
+	}
+
+	role, err := rc.roleService.GetRole(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", role))
+}
+
+func (rc *RoleController) GetPermissions(c *gin.Context) {
+	permissions, err := rc.roleService.GetPermissions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch permissions"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", permissions))
+}
+
+func (rc *RoleController) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	role, err := rc.roleService.CreateRole(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("role created", role))
+}
+
+func (rc *RoleController) UpdateRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid role id"))
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	role, err := rc.roleService.UpdateRole(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("role updated", role))
+}
+
+func (rc *RoleController) DeleteRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid role id"))
+		return
+	}
+
+	if err := rc.roleService.DeleteRole(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("role deleted", nil))
+}
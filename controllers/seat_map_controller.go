@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeatMapController struct {
+	seatMapService services.SeatMapService
+}
+
+func NewSeatMapController(seatMapService services.SeatMapService) *SeatMapController {
+	return &SeatMapController{
+		seatMapService: seatMapService,
+	}
+}
+
+func (sc *SeatMapController) GetEventSeats(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	seatMap, err := sc.seatMapService.GetEventSeats(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", seatMap))
+}
+
+func (sc *SeatMapController) UpdateEventSeatMap(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	var req dto.UpdateSeatMapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	seatMap, err := sc.seatMapService.UpdateEventSeatMap(uint(eventID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("seat map updated", seatMap))
+}
+
+func (sc *SeatMapController) GetVenueSeatMap(c *gin.Context) {
+	venueID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid venue id"))
+		return
+	}
+
+	seatMap, err := sc.seatMapService.GetVenueSeatMap(uint(venueID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", seatMap))
+}
+
+func (sc *SeatMapController) UpdateVenueSeatMap(c *gin.Context) {
+	venueID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid venue id"))
+		return
+	}
+
+	var req dto.UpdateSeatMapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	seatMap, err := sc.seatMapService.UpdateVenueSeatMap(uint(venueID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("seat map updated", seatMap))
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettingController struct {
+	settingService services.SettingService
+}
+
+func NewSettingController(settingService services.SettingService) *SettingController {
+	return &SettingController{
+		settingService: settingService,
+	}
+}
+
+func (sc *SettingController) GetSettings(c *gin.Context) {
+	settings, err := sc.settingService.GetSettings()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch settings"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", settings))
+}
+
+func (sc *SettingController) UpdateSettings(c *gin.Context) {
+	var req dto.UpdateSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	settings, err := sc.settingService.UpdateSettings(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to update settings"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("settings updated", settings))
+}
@@ -1,9 +1,13 @@
 package controllers
 
 import (
+	"case_study_api/constants"
 	"case_study_api/dto"
+	"case_study_api/middleware"
 	"case_study_api/services"
 	"case_study_api/utils"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -24,6 +28,16 @@ func (tc *TicketController) GetTicketsPaginated(c *gin.Context) {
 	userID := c.MustGet("user_id").(uint)
 	pagination := utils.GetPaginationFromQuery(c)
 
+	if pagination.UseCursor {
+		result, err := tc.ticketService.GetUserTicketsByCursor(userID, pagination)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+			return
+		}
+		c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", result))
+		return
+	}
+
 	result, err := tc.ticketService.GetUserTicketsPaginated(userID, pagination)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch tickets"))
@@ -78,9 +92,90 @@ func (tc *TicketController) CancelTicket(c *gin.Context) {
 	}
 
 	userID := c.MustGet("user_id").(uint)
-	if err := tc.ticketService.CancelTicket(uint(id), userID, req); err != nil {
+	refund, err := tc.ticketService.CancelTicket(uint(id), userID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("ticket cancelled", refund))
+}
+
+func (tc *TicketController) HoldTicket(c *gin.Context) {
+	var req dto.HoldTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	hold, err := tc.ticketService.HoldTickets(req, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("tickets held", hold))
+}
+
+func (tc *TicketController) ConfirmHold(c *gin.Context) {
+	// The body is optional, confirming without one pays with the defaults
+	var req dto.ConfirmHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	ticket, err := tc.ticketService.ConfirmHold(c.Param("token"), userID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("ticket booked", ticket))
+}
+
+func (tc *TicketController) ReleaseHold(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+	if err := tc.ticketService.ReleaseHold(c.Param("token"), userID); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("hold released", nil))
+}
+
+func (tc *TicketController) GetTicketQRCode(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	isAdmin := middleware.HasPermission(c, constants.PermissionTicketsRead)
+	png, err := tc.ticketService.GetTicketQRCode(uint(id), userID, isAdmin)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
 		return
 	}
-	c.JSON(http.StatusOK, utils.BuildSuccessResponse("ticket cancelled", nil))
+
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+func (tc *TicketController) DownloadTicketPDF(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	isAdmin := middleware.HasPermission(c, constants.PermissionTicketsRead)
+	pdfData, filename, err := tc.ticketService.GenerateTicketPDF(uint(id), userID, isAdmin)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/pdf", pdfData)
 }
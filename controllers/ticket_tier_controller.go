@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TicketTierController struct {
+	tierService services.TicketTierService
+}
+
+func NewTicketTierController(tierService services.TicketTierService) *TicketTierController {
+	return &TicketTierController{
+		tierService: tierService,
+	}
+}
+
+func (tc *TicketTierController) GetTiers(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	tiers, err := tc.tierService.GetByEventID(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", tiers))
+}
+
+func (tc *TicketTierController) CreateTier(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	var req dto.CreateTicketTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	tier, err := tc.tierService.Create(uint(eventID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("tier created", tier))
+}
+
+func (tc *TicketTierController) UpdateTier(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	tierID, err := strconv.Atoi(c.Param("tier_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid tier id"))
+		return
+	}
+
+	var req dto.UpdateTicketTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	tier, err := tc.tierService.Update(uint(eventID), uint(tierID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("tier updated", tier))
+}
+
+func (tc *TicketTierController) DeleteTier(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	tierID, err := strconv.Atoi(c.Param("tier_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid tier id"))
+		return
+	}
+
+	if err := tc.tierService.Delete(uint(eventID), uint(tierID)); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("tier deleted", nil))
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueController struct {
+	venueService services.VenueService
+}
+
+func NewVenueController(venueService services.VenueService) *VenueController {
+	return &VenueController{
+		venueService: venueService,
+	}
+}
+
+func (vc *VenueController) GetVenues(c *gin.Context) {
+	venues, err := vc.venueService.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch venues"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", venues))
+}
+
+func (vc *VenueController) GetVenue(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	venue, err := vc.venueService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", venue))
+}
+
+func (vc *VenueController) CreateVenue(c *gin.Context) {
+	var req dto.CreateVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	venue, err := vc.venueService.Create(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("venue created", venue))
+}
+
+func (vc *VenueController) UpdateVenue(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	var req dto.UpdateVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	venue, err := vc.venueService.Update(uint(id), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("venue updated", venue))
+}
+
+func (vc *VenueController) DeleteVenue(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	if err := vc.venueService.Delete(uint(id)); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("venue deleted", nil))
+}
+
+func (vc *VenueController) CreateHall(c *gin.Context) {
+	venueID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid venue id"))
+		return
+	}
+
+	var req dto.CreateVenueHallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	hall, err := vc.venueService.CreateHall(uint(venueID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("hall created", hall))
+}
+
+func (vc *VenueController) UpdateHall(c *gin.Context) {
+	venueID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid venue id"))
+		return
+	}
+
+	hallID, err := strconv.Atoi(c.Param("hall_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid hall id"))
+		return
+	}
+
+	var req dto.UpdateVenueHallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	hall, err := vc.venueService.UpdateHall(uint(venueID), uint(hallID), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("hall updated", hall))
+}
+
+func (vc *VenueController) DeleteHall(c *gin.Context) {
+	venueID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid venue id"))
+		return
+	}
+
+	hallID, err := strconv.Atoi(c.Param("hall_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid hall id"))
+		return
+	}
+
+	if err := vc.venueService.DeleteHall(uint(venueID), uint(hallID)); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("hall deleted", nil))
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"case_study_api/dto"
+	"case_study_api/services"
+	"case_study_api/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistController struct {
+	waitlistService services.WaitlistService
+}
+
+func NewWaitlistController(waitlistService services.WaitlistService) *WaitlistController {
+	return &WaitlistController{
+		waitlistService: waitlistService,
+	}
+}
+
+func (wc *WaitlistController) JoinWaitlist(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	var req dto.JoinWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid request body"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	entry, err := wc.waitlistService.Join(uint(eventID), userID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusCreated, utils.BuildSuccessResponse("joined waitlist", entry))
+}
+
+func (wc *WaitlistController) GetMyWaitlist(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+	entries, err := wc.waitlistService.GetUserEntries(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to fetch waitlist"))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", entries))
+}
+
+func (wc *WaitlistController) LeaveWaitlist(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid id"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(uint)
+	if err := wc.waitlistService.Leave(uint(id), userID); err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("left waitlist", nil))
+}
+
+func (wc *WaitlistController) GetEventWaitlist(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, utils.BuildErrorResponse("invalid event id"))
+		return
+	}
+
+	demand, err := wc.waitlistService.GetEventDemand(uint(eventID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, utils.BuildSuccessResponse("success", demand))
+}
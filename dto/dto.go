@@ -13,22 +13,156 @@ type LoginRequest struct {
 }
 
 type AuthResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Token string `json:"token"`
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the number of seconds Token stays valid
+	ExpiresIn     int  `json:"expires_in"`
+	EmailVerified bool `json:"email_verified"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// LogoutRequest revokes the refresh token of the session being closed, or
+// with AllSessions every refresh token of the user.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	AllSessions  bool   `json:"all_sessions"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+// Profile DTOs
+type ProfileResponse struct {
+	ID            uint   `json:"id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Phone         string `json:"phone"`
+	Role          string `json:"role"`
+	EmailVerified bool   `json:"email_verified"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// UpdateProfileRequest changes the fields that are not empty. A new email
+// address has to be verified again; a phone of "" removes the number.
+type UpdateProfileRequest struct {
+	Name  string  `json:"name" binding:"omitempty,max=255"`
+	Email string  `json:"email" binding:"omitempty,email"`
+	Phone *string `json:"phone" binding:"omitempty,max=30"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+// DeleteAccountRequest confirms account deletion with the user's password.
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// Admin User DTOs
+type UserListQuery struct {
+	Role   string `form:"role"`
+	Status string `form:"status"`
+	// RegisteredFrom and RegisteredTo take a date or a full timestamp
+	RegisteredFrom string `form:"registered_from"`
+	RegisteredTo   string `form:"registered_to"`
+	Search         string `form:"q"`
+}
+
+type AdminUserResponse struct {
+	ID               uint    `json:"id"`
+	Name             string  `json:"name"`
+	Email            string  `json:"email"`
+	Phone            string  `json:"phone"`
+	Role             string  `json:"role"`
+	EmailVerified    bool    `json:"email_verified"`
+	Status           string  `json:"status"`
+	SuspendedAt      *string `json:"suspended_at,omitempty"`
+	SuspensionReason string  `json:"suspension_reason,omitempty"`
+	Deleted          bool    `json:"deleted"`
+	CreatedAt        string  `json:"created_at"`
+}
+
+type AdminUserDetailResponse struct {
+	AdminUserResponse
+	TicketCount int              `json:"ticket_count"`
+	TotalSpend  float64          `json:"total_spend"`
+	Tickets     []TicketResponse `json:"tickets"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Role DTOs
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleResponse struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsSystem    bool     `json:"is_system"`
+	Permissions []string `json:"permissions"`
+}
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRoleRequest changes the description and, when present, replaces
+// the role's permissions. Role names cannot change, since users refer to
+// their role by name.
+type UpdateRoleRequest struct {
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+// Setting DTOs
+type SettingsResponse struct {
+	RequireEmailVerification bool `json:"require_email_verification"`
+}
+
+// UpdateSettingsRequest changes the settings that are present and keeps the
+// others.
+type UpdateSettingsRequest struct {
+	RequireEmailVerification *bool `json:"require_email_verification"`
 }
 
 // Event DTOs
 type CreateEventRequest struct {
 	Title       string  `json:"title" binding:"required"`
 	Description string  `json:"description"`
-	Location    string  `json:"location" binding:"required"`
+	Location    string  `json:"location"`
 	Category    string  `json:"category" binding:"required"`
 	Date        string  `json:"date" binding:"required"`
 	EndDate     string  `json:"end_date"`
 	Capacity    int     `json:"capacity" binding:"required,min=1"`
 	Price       float64 `json:"price" binding:"required,min=0"`
+	// Location may be left out when the event is held at a venue
+	VenueID *uint `json:"venue_id"`
+	HallID  *uint `json:"hall_id"`
 }
 
 type UpdateEventRequest struct {
@@ -41,6 +175,60 @@ type UpdateEventRequest struct {
 	Capacity    int     `json:"capacity" binding:"min=1"`
 	Price       float64 `json:"price" binding:"min=0"`
 	Status      string  `json:"status"`
+	// StatusReason is recorded in the status history when Status changes
+	StatusReason string `json:"status_reason"`
+	// A venue_id of 0 removes the event from its venue
+	VenueID *uint `json:"venue_id"`
+	HallID  *uint `json:"hall_id"`
+}
+
+type UpdateEventStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+type CancelEventRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type EventCancellationResponse struct {
+	EventID          uint    `json:"event_id"`
+	Status           string  `json:"status"`
+	Reason           string  `json:"reason"`
+	TotalTickets     int64   `json:"total_tickets"`
+	ProcessedTickets int64   `json:"processed_tickets"`
+	RefundedAmount   float64 `json:"refunded_amount"`
+	LastError        string  `json:"last_error,omitempty"`
+	RequestedAt      string  `json:"requested_at"`
+	StartedAt        *string `json:"started_at,omitempty"`
+	CompletedAt      *string `json:"completed_at,omitempty"`
+}
+
+type EventStatusHistoryResponse struct {
+	ID         uint   `json:"id"`
+	EventID    uint   `json:"event_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	ActorID    *uint  `json:"actor_id"`
+	ActorName  string `json:"actor_name"`
+	Reason     string `json:"reason,omitempty"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+// EventListQuery holds the filters and sort accepted by GET /api/events.
+// Dates use the same format as event dates, or just YYYY-MM-DD.
+type EventListQuery struct {
+	Category        string   `form:"category"`
+	Status          string   `form:"status"`
+	DateFrom        string   `form:"date_from"`
+	DateTo          string   `form:"date_to"`
+	MinPrice        *float64 `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice        *float64 `form:"max_price" binding:"omitempty,min=0"`
+	Location        string   `form:"location"`
+	HasAvailability bool     `form:"has_availability"`
+	Search          string   `form:"q"`
+	Sort            string   `form:"sort"`
+	Order           string   `form:"order"`
 }
 
 type EventResponse struct {
@@ -57,12 +245,354 @@ type EventResponse struct {
 	SoldTickets int     `json:"sold_tickets"`
 	CreatedBy   uint    `json:"created_by"`
 	IsActive    bool    `json:"is_active"`
+	VenueID     *uint   `json:"venue_id"`
+	HallID      *uint   `json:"hall_id"`
+	SeriesID    *uint   `json:"series_id"`
+}
+
+// Event Series DTOs
+type CreateEventSeriesRequest struct {
+	Title       string `json:"title" binding:"required,max=200"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	Category    string `json:"category" binding:"required"`
+	// RRule is an RFC 5545 recurrence rule such as FREQ=WEEKLY;BYDAY=SA;COUNT=8.
+	// StartsAt anchors the rule and sets the time of day of every occurrence.
+	RRule           string                        `json:"rrule" binding:"required"`
+	StartsAt        string                        `json:"starts_at" binding:"required"`
+	DurationMinutes int                           `json:"duration_minutes" binding:"required,min=1"`
+	Capacity        int                           `json:"capacity" binding:"required,min=1"`
+	Price           float64                       `json:"price" binding:"min=0"`
+	VenueID         *uint                         `json:"venue_id"`
+	HallID          *uint                         `json:"hall_id"`
+	Exceptions      []EventSeriesExceptionRequest `json:"exceptions" binding:"dive"`
+}
+
+// EventSeriesExceptionRequest skips the occurrence on Date, as YYYY-MM-DD.
+type EventSeriesExceptionRequest struct {
+	Date   string `json:"date" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// UpdateSeriesOccurrenceRequest edits one occurrence of a series, or with
+// the future scope that occurrence, every later one and the series itself.
+type UpdateSeriesOccurrenceRequest struct {
+	Scope       string   `json:"scope" binding:"required,oneof=this future"`
+	Title       string   `json:"title" binding:"max=200"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	Category    string   `json:"category"`
+	Capacity    int      `json:"capacity" binding:"omitempty,min=1"`
+	Price       *float64 `json:"price" binding:"omitempty,min=0"`
+	// StartTime moves the occurrences to another time of the same day, as HH:MM
+	StartTime       string `json:"start_time"`
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1"`
+}
+
+type EventSeriesResponse struct {
+	ID              uint                           `json:"id"`
+	Title           string                         `json:"title"`
+	Description     string                         `json:"description"`
+	Location        string                         `json:"location"`
+	Category        string                         `json:"category"`
+	RRule           string                         `json:"rrule"`
+	StartsAt        string                         `json:"starts_at"`
+	DurationMinutes int                            `json:"duration_minutes"`
+	Capacity        int                            `json:"capacity"`
+	Price           float64                        `json:"price"`
+	VenueID         *uint                          `json:"venue_id"`
+	HallID          *uint                          `json:"hall_id"`
+	CreatedBy       uint                           `json:"created_by"`
+	Exceptions      []EventSeriesExceptionResponse `json:"exceptions,omitempty"`
+	Occurrences     []EventResponse                `json:"occurrences,omitempty"`
+}
+
+type EventSeriesExceptionResponse struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// CalendarFeedResponse carries a personal calendar feed URL. The token in
+// it is only shown once.
+type CalendarFeedResponse struct {
+	FeedURL string `json:"feed_url"`
+}
+
+// Agenda DTOs
+type CreateSessionRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Room        string `json:"room"`
+	StartTime   string `json:"start_time" binding:"required"`
+	EndTime     string `json:"end_time" binding:"required"`
+	Capacity    int    `json:"capacity" binding:"required,min=1"`
+	SpeakerIDs  []uint `json:"speaker_ids"`
+}
+
+type UpdateSessionRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Room        string `json:"room"`
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	Capacity    int    `json:"capacity" binding:"omitempty,min=1"`
+	// SpeakerIDs replaces the speakers when present; an empty list removes all
+	SpeakerIDs []uint `json:"speaker_ids"`
+}
+
+type SessionResponse struct {
+	ID           uint              `json:"id"`
+	EventID      uint              `json:"event_id"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	Room         string            `json:"room"`
+	StartTime    string            `json:"start_time"`
+	EndTime      string            `json:"end_time"`
+	Capacity     int               `json:"capacity"`
+	Registered   int               `json:"registered"`
+	Available    int               `json:"available"`
+	IsRegistered bool              `json:"is_registered,omitempty"`
+	Speakers     []SpeakerResponse `json:"speakers"`
+}
+
+type CreateSpeakerRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Headline string `json:"headline"`
+	Company  string `json:"company"`
+	Bio      string `json:"bio"`
+	PhotoURL string `json:"photo_url" binding:"omitempty,url"`
+}
+
+type UpdateSpeakerRequest struct {
+	Name     string `json:"name"`
+	Headline string `json:"headline"`
+	Company  string `json:"company"`
+	Bio      string `json:"bio"`
+	PhotoURL string `json:"photo_url" binding:"omitempty,url"`
+}
+
+type SpeakerResponse struct {
+	ID       uint   `json:"id"`
+	EventID  uint   `json:"event_id"`
+	Name     string `json:"name"`
+	Headline string `json:"headline"`
+	Company  string `json:"company"`
+	Bio      string `json:"bio"`
+	PhotoURL string `json:"photo_url"`
+}
+
+// AgendaResponse is the public programme of an event, with its sessions
+// grouped by day.
+type AgendaResponse struct {
+	EventID  uint                `json:"event_id"`
+	Title    string              `json:"title"`
+	Location string              `json:"location"`
+	Date     string              `json:"date"`
+	EndDate  string              `json:"end_date"`
+	Days     []AgendaDayResponse `json:"days"`
+	Speakers []SpeakerResponse   `json:"speakers"`
+}
+
+type AgendaDayResponse struct {
+	Date     string            `json:"date"`
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// Venue DTOs
+type CreateVenueRequest struct {
+	Name        string                   `json:"name" binding:"required"`
+	Address     string                   `json:"address" binding:"required"`
+	City        string                   `json:"city" binding:"required"`
+	Latitude    *float64                 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude   *float64                 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Timezone    string                   `json:"timezone" binding:"required"`
+	MaxCapacity int                      `json:"max_capacity" binding:"required,min=1"`
+	Halls       []CreateVenueHallRequest `json:"halls" binding:"dive"`
+}
+
+type UpdateVenueRequest struct {
+	Name        string   `json:"name"`
+	Address     string   `json:"address"`
+	City        string   `json:"city"`
+	Latitude    *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude   *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Timezone    string   `json:"timezone"`
+	MaxCapacity int      `json:"max_capacity" binding:"omitempty,min=1"`
+}
+
+type CreateVenueHallRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Capacity int    `json:"capacity" binding:"required,min=1"`
+}
+
+type UpdateVenueHallRequest struct {
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity" binding:"omitempty,min=1"`
+}
+
+type VenueResponse struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Address     string              `json:"address"`
+	City        string              `json:"city"`
+	Latitude    *float64            `json:"latitude"`
+	Longitude   *float64            `json:"longitude"`
+	Timezone    string              `json:"timezone"`
+	MaxCapacity int                 `json:"max_capacity"`
+	Halls       []VenueHallResponse `json:"halls"`
+}
+
+type VenueHallResponse struct {
+	ID       uint   `json:"id"`
+	VenueID  uint   `json:"venue_id"`
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity"`
+}
+
+// Ticket Tier DTOs
+type CreateTicketTierRequest struct {
+	Name       string  `json:"name" binding:"required"`
+	Price      float64 `json:"price" binding:"min=0"`
+	Capacity   int     `json:"capacity" binding:"required,min=1"`
+	SalesStart string  `json:"sales_start"`
+	SalesEnd   string  `json:"sales_end"`
+}
+
+type UpdateTicketTierRequest struct {
+	Name       string   `json:"name"`
+	Price      *float64 `json:"price" binding:"omitempty,min=0"`
+	Capacity   int      `json:"capacity" binding:"omitempty,min=1"`
+	SalesStart string   `json:"sales_start"`
+	SalesEnd   string   `json:"sales_end"`
+}
+
+type TicketTierResponse struct {
+	ID         uint    `json:"id"`
+	EventID    uint    `json:"event_id"`
+	Name       string  `json:"name"`
+	Price      float64 `json:"price"`
+	Capacity   int     `json:"capacity"`
+	Sold       int     `json:"sold"`
+	Available  int     `json:"available"`
+	SalesStart *string `json:"sales_start,omitempty"`
+	SalesEnd   *string `json:"sales_end,omitempty"`
 }
 
 // Ticket DTOs
 type CreateTicketRequest struct {
-	EventID  uint `json:"event_id" binding:"required"`
-	Quantity int  `json:"quantity" binding:"required,min=1"`
+	EventID         uint   `json:"event_id" binding:"required"`
+	Quantity        int    `json:"quantity" binding:"required,min=1"`
+	TierID          *uint  `json:"tier_id"`
+	PromoCode       string `json:"promo_code"`
+	PaymentScenario string `json:"payment_scenario"`
+	// SeatIDs picks one seat per ticket on events with reserved seating.
+	// A seat in a priced section costs the section price, not the tier price.
+	SeatIDs []uint `json:"seat_ids"`
+}
+
+type ConfirmHoldRequest struct {
+	PromoCode       string `json:"promo_code"`
+	PaymentScenario string `json:"payment_scenario"`
+}
+
+type HoldTicketRequest struct {
+	EventID  uint   `json:"event_id" binding:"required"`
+	Quantity int    `json:"quantity" binding:"required,min=1"`
+	TierID   *uint  `json:"tier_id"`
+	SeatIDs  []uint `json:"seat_ids"`
+}
+
+type TicketHoldResponse struct {
+	Token     string `json:"token"`
+	EventID   uint   `json:"event_id"`
+	Quantity  int    `json:"quantity"`
+	Status    string `json:"status"`
+	ExpiresAt string `json:"expires_at"`
+	TierID    *uint  `json:"tier_id,omitempty"`
+	TicketID  *uint  `json:"ticket_id,omitempty"`
+	SeatIDs   []uint `json:"seat_ids,omitempty"`
+}
+
+// Seat Map DTOs
+type UpdateSeatMapRequest struct {
+	Sections []SeatSectionRequest `json:"sections" binding:"dive"`
+	// CopyFromVenue replaces the event seat map with its venue's template
+	CopyFromVenue bool `json:"copy_from_venue"`
+}
+
+type SeatSectionRequest struct {
+	Name  string           `json:"name" binding:"required"`
+	Price *float64         `json:"price" binding:"omitempty,min=0"`
+	Rows  []SeatRowRequest `json:"rows" binding:"required,min=1,dive"`
+}
+
+// SeatRowRequest describes a row of seats numbered 1 to Seats
+type SeatRowRequest struct {
+	Label string `json:"label" binding:"required,max=10"`
+	Seats int    `json:"seats" binding:"required,min=1,max=500"`
+}
+
+type SeatMapResponse struct {
+	EventID        *uint                 `json:"event_id,omitempty"`
+	VenueID        *uint                 `json:"venue_id,omitempty"`
+	TotalSeats     int                   `json:"total_seats"`
+	AvailableSeats int                   `json:"available_seats"`
+	Sections       []SeatSectionResponse `json:"sections"`
+}
+
+type SeatSectionResponse struct {
+	ID    uint           `json:"id"`
+	Name  string         `json:"name"`
+	Price *float64       `json:"price"`
+	Seats []SeatResponse `json:"seats"`
+}
+
+type SeatResponse struct {
+	ID     uint   `json:"id"`
+	Row    string `json:"row"`
+	Number int    `json:"number"`
+	Status string `json:"status,omitempty"`
+}
+
+type TicketSeatResponse struct {
+	ID      uint   `json:"id"`
+	Section string `json:"section"`
+	Row     string `json:"row"`
+	Number  int    `json:"number"`
+}
+
+// Waitlist DTOs
+type JoinWaitlistRequest struct {
+	Quantity int   `json:"quantity" binding:"required,min=1"`
+	TierID   *uint `json:"tier_id"`
+}
+
+type WaitlistEntryResponse struct {
+	ID         uint                `json:"id"`
+	EventID    uint                `json:"event_id"`
+	EventTitle string              `json:"event_title,omitempty"`
+	TierID     *uint               `json:"tier_id,omitempty"`
+	Quantity   int                 `json:"quantity"`
+	Status     string              `json:"status"`
+	Position   int64               `json:"position,omitempty"`
+	JoinedAt   string              `json:"joined_at"`
+	OfferedAt  *string             `json:"offered_at,omitempty"`
+	Hold       *TicketHoldResponse `json:"hold,omitempty"`
+}
+
+type WaitlistDemandResponse struct {
+	EventID          uint                    `json:"event_id"`
+	Title            string                  `json:"title"`
+	Capacity         int                     `json:"capacity"`
+	SoldTickets      int                     `json:"sold_tickets"`
+	WaitingEntries   int64                   `json:"waiting_entries"`
+	WaitingQuantity  int64                   `json:"waiting_quantity"`
+	OfferedEntries   int64                   `json:"offered_entries"`
+	OfferedQuantity  int64                   `json:"offered_quantity"`
+	FulfilledEntries int64                   `json:"fulfilled_entries"`
+	ExpiredEntries   int64                   `json:"expired_entries"`
+	DeclinedEntries  int64                   `json:"declined_entries"`
+	Entries          []WaitlistEntryResponse `json:"entries"`
 }
 
 type CancelTicketRequest struct {
@@ -70,18 +600,194 @@ type CancelTicketRequest struct {
 }
 
 type TicketResponse struct {
-	ID           uint           `json:"id"`
-	UserID       uint           `json:"user_id"`
-	EventID      uint           `json:"event_id"`
-	Quantity     int            `json:"quantity"`
-	UnitPrice    float64        `json:"unit_price"`
-	TotalPrice   float64        `json:"total_price"`
-	Status       string         `json:"status"`
-	BookingCode  string         `json:"booking_code"`
-	PurchaseDate string         `json:"purchase_date"`
-	CancelledAt  *string        `json:"cancelled_at,omitempty"`
-	CancelReason string         `json:"cancel_reason,omitempty"`
-	Event        *EventResponse `json:"event,omitempty"`
+	ID             uint                 `json:"id"`
+	UserID         uint                 `json:"user_id"`
+	EventID        uint                 `json:"event_id"`
+	TierID         *uint                `json:"tier_id,omitempty"`
+	TierName       string               `json:"tier_name,omitempty"`
+	Quantity       int                  `json:"quantity"`
+	UnitPrice      float64              `json:"unit_price"`
+	DiscountAmount float64              `json:"discount_amount"`
+	PromoCode      string               `json:"promo_code,omitempty"`
+	TotalPrice     float64              `json:"total_price"`
+	Status         string               `json:"status"`
+	BookingCode    string               `json:"booking_code"`
+	PurchaseDate   string               `json:"purchase_date"`
+	CancelledAt    *string              `json:"cancelled_at,omitempty"`
+	CancelReason   string               `json:"cancel_reason,omitempty"`
+	CheckedInCount int                  `json:"checked_in_count"`
+	CheckedInAt    *string              `json:"checked_in_at,omitempty"`
+	Seats          []TicketSeatResponse `json:"seats,omitempty"`
+	Event          *EventResponse       `json:"event,omitempty"`
+	Order          *OrderResponse       `json:"order,omitempty"`
+	Refund         *RefundResponse      `json:"refund,omitempty"`
+}
+
+// Promo Code DTOs
+type CreatePromoCodeRequest struct {
+	Code           string  `json:"code" binding:"required,max=50"`
+	Description    string  `json:"description"`
+	DiscountType   string  `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue  float64 `json:"discount_value" binding:"required,gt=0"`
+	EventID        *uint   `json:"event_id"`
+	ValidFrom      string  `json:"valid_from"`
+	ValidUntil     string  `json:"valid_until"`
+	MaxRedemptions int     `json:"max_redemptions" binding:"min=0"`
+	PerUserLimit   int     `json:"per_user_limit" binding:"min=0"`
+	MinQuantity    int     `json:"min_quantity" binding:"min=0"`
+}
+
+type UpdatePromoCodeRequest struct {
+	Description    string   `json:"description"`
+	DiscountType   string   `json:"discount_type" binding:"omitempty,oneof=percentage fixed"`
+	DiscountValue  *float64 `json:"discount_value" binding:"omitempty,gt=0"`
+	ValidFrom      string   `json:"valid_from"`
+	ValidUntil     string   `json:"valid_until"`
+	MaxRedemptions *int     `json:"max_redemptions" binding:"omitempty,min=0"`
+	PerUserLimit   *int     `json:"per_user_limit" binding:"omitempty,min=0"`
+	MinQuantity    *int     `json:"min_quantity" binding:"omitempty,min=1"`
+	IsActive       *bool    `json:"is_active"`
+}
+
+type PromoCodeResponse struct {
+	ID             uint    `json:"id"`
+	Code           string  `json:"code"`
+	Description    string  `json:"description"`
+	DiscountType   string  `json:"discount_type"`
+	DiscountValue  float64 `json:"discount_value"`
+	EventID        *uint   `json:"event_id,omitempty"`
+	ValidFrom      *string `json:"valid_from,omitempty"`
+	ValidUntil     *string `json:"valid_until,omitempty"`
+	MaxRedemptions int     `json:"max_redemptions"`
+	PerUserLimit   int     `json:"per_user_limit"`
+	MinQuantity    int     `json:"min_quantity"`
+	Redemptions    int     `json:"redemptions"`
+	IsActive       bool    `json:"is_active"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+type PromoRedemptionResponse struct {
+	ID          uint    `json:"id"`
+	UserID      uint    `json:"user_id"`
+	UserName    string  `json:"user_name"`
+	TicketID    uint    `json:"ticket_id"`
+	BookingCode string  `json:"booking_code"`
+	Discount    float64 `json:"discount"`
+	Status      string  `json:"status"`
+	RedeemedAt  string  `json:"redeemed_at"`
+}
+
+type PromoRedemptionReportResponse struct {
+	PromoCode        PromoCodeResponse         `json:"promo_code"`
+	TotalRedemptions int64                     `json:"total_redemptions"`
+	UniqueUsers      int64                     `json:"unique_users"`
+	TotalDiscount    float64                   `json:"total_discount"`
+	TicketsSold      int                       `json:"tickets_sold"`
+	Revenue          float64                   `json:"revenue"`
+	Redemptions      []PromoRedemptionResponse `json:"redemptions"`
+}
+
+// Event Lifecycle DTOs
+type LifecycleRunResponse struct {
+	RanAt           string `json:"ran_at"`
+	EventsStarted   int    `json:"events_started"`
+	EventsCompleted int    `json:"events_completed"`
+	NoShowTickets   int64  `json:"no_show_tickets"`
+}
+
+type LifecycleStatsResponse struct {
+	Runs                 int64                 `json:"runs"`
+	TotalEventsStarted   int64                 `json:"total_events_started"`
+	TotalEventsCompleted int64                 `json:"total_events_completed"`
+	TotalNoShowTickets   int64                 `json:"total_no_show_tickets"`
+	LastRun              *LifecycleRunResponse `json:"last_run,omitempty"`
+	LastError            string                `json:"last_error,omitempty"`
+	LastErrorAt          *string               `json:"last_error_at,omitempty"`
+}
+
+// Refund DTOs
+type RefundResponse struct {
+	ID          uint    `json:"id"`
+	TicketID    uint    `json:"ticket_id"`
+	OrderID     uint    `json:"order_id"`
+	Amount      float64 `json:"amount"`
+	Percentage  float64 `json:"percentage"`
+	Status      string  `json:"status"`
+	Reason      string  `json:"reason,omitempty"`
+	ProcessedAt *string `json:"processed_at,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+type RefundPolicyRule struct {
+	MinHoursBefore int     `json:"min_hours_before" binding:"min=0"`
+	Percentage     float64 `json:"percentage" binding:"min=0,max=100"`
+}
+
+type UpdateRefundPolicyRequest struct {
+	Rules []RefundPolicyRule `json:"rules" binding:"dive"`
+}
+
+type RefundPolicyResponse struct {
+	EventID uint               `json:"event_id"`
+	Rules   []RefundPolicyRule `json:"rules"`
+	// Default is set when the event has no rules and every cancellation is
+	// refunded in full.
+	Default bool `json:"default"`
+}
+
+// Order and Payment DTOs
+type OrderResponse struct {
+	ID        uint              `json:"id"`
+	TicketID  uint              `json:"ticket_id"`
+	Amount    float64           `json:"amount"`
+	Status    string            `json:"status"`
+	ExpiresAt string            `json:"expires_at"`
+	PaidAt    *string           `json:"paid_at,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	Payments  []PaymentResponse `json:"payments"`
+	Ticket    *TicketResponse   `json:"ticket,omitempty"`
+}
+
+type PaymentResponse struct {
+	ID         uint    `json:"id"`
+	Gateway    string  `json:"gateway"`
+	Reference  string  `json:"reference"`
+	Amount     float64 `json:"amount"`
+	Status     string  `json:"status"`
+	PaymentURL string  `json:"payment_url,omitempty"`
+	PaidAt     *string `json:"paid_at,omitempty"`
+}
+
+// Check-in DTOs
+type CheckInRequest struct {
+	BookingCode string `json:"booking_code" binding:"required_without=QRPayload"`
+	QRPayload   string `json:"qr_payload"`
+	EventID     uint   `json:"event_id"`
+	Count       int    `json:"count" binding:"omitempty,min=1"`
+}
+
+type VerifyTicketRequest struct {
+	QRPayload string `json:"qr_payload" binding:"required"`
+}
+
+type VerifyTicketResponse struct {
+	Valid    bool `json:"valid"`
+	TicketID uint `json:"ticket_id"`
+	EventID  uint `json:"event_id"`
+}
+
+type CheckInResponse struct {
+	TicketID       uint   `json:"ticket_id"`
+	BookingCode    string `json:"booking_code"`
+	EventID        uint   `json:"event_id"`
+	EventTitle     string `json:"event_title"`
+	Quantity       int    `json:"quantity"`
+	Admitted       int    `json:"admitted"`
+	CheckedInCount int    `json:"checked_in_count"`
+	Remaining      int    `json:"remaining"`
+	Status         string `json:"status"`
+	CheckedInAt    string `json:"checked_in_at"`
+	CheckedInBy    uint   `json:"checked_in_by"`
 }
 
 // Report DTOs
@@ -91,8 +797,40 @@ type SummaryReportResponse struct {
 }
 
 type EventReportResponse struct {
+	EventID     uint              `json:"event_id"`
+	Title       string            `json:"title"`
+	TicketsSold int               `json:"tickets_sold"`
+	Revenue     float64           `json:"revenue"`
+	Tiers       []TierSalesReport `json:"tiers"`
+}
+
+// SeriesReportResponse rolls up the sales of every occurrence of a series.
+// Revenue is net of refunds.
+type SeriesReportResponse struct {
+	SeriesID      uint                     `json:"series_id"`
+	Title         string                   `json:"title"`
+	Occurrences   int                      `json:"occurrences"`
+	TotalCapacity int                      `json:"total_capacity"`
+	TicketsSold   int                      `json:"tickets_sold"`
+	Revenue       float64                  `json:"revenue"`
+	Events        []SeriesOccurrenceReport `json:"events"`
+}
+
+type SeriesOccurrenceReport struct {
 	EventID     uint    `json:"event_id"`
 	Title       string  `json:"title"`
+	Date        string  `json:"date"`
+	Status      string  `json:"status"`
+	Capacity    int     `json:"capacity"`
+	TicketsSold int     `json:"tickets_sold"`
+	Revenue     float64 `json:"revenue"`
+}
+
+type TierSalesReport struct {
+	TierID      uint    `json:"tier_id"`
+	Name        string  `json:"name"`
+	Price       float64 `json:"price"`
+	Capacity    int     `json:"capacity"`
 	TicketsSold int     `json:"tickets_sold"`
 	Revenue     float64 `json:"revenue"`
 }
@@ -8,12 +8,80 @@ import (
 
 type User struct {
 	gorm.Model
-	Name     string   `gorm:"type:varchar(255);not null"`
-	Email    string   `gorm:"unique;not null;type:varchar(255)"`
-	Password string   `gorm:"type:varchar(255);not null"`
-	Role     string   `gorm:"type:enum('user','admin');default:'user'"`
-	Events   []Event  `gorm:"foreignKey:CreatedBy"`
-	Tickets  []Ticket `gorm:"foreignKey:UserID"`
+	Name     string `gorm:"type:varchar(255);not null"`
+	Email    string `gorm:"unique;not null;type:varchar(255)"`
+	Password string `gorm:"type:varchar(255);not null"`
+	// Role names a Role; it decides the user's permissions
+	Role  string `gorm:"type:varchar(50);default:'user';index"`
+	Phone string `gorm:"type:varchar(30)"`
+	// CalendarTokenHash authenticates the personal calendar feed
+	CalendarTokenHash *string `gorm:"uniqueIndex;type:char(64)"`
+	EmailVerifiedAt   *time.Time
+	// AnonymizedAt is set when the user deleted their account. The row is
+	// kept, stripped of personal data, so tickets and reports stay intact.
+	AnonymizedAt *time.Time
+	// A suspended user is rejected on every authenticated request
+	SuspendedAt      *time.Time `gorm:"index"`
+	SuspensionReason string     `gorm:"type:text"`
+	Events           []Event    `gorm:"foreignKey:CreatedBy"`
+	Tickets          []Ticket   `gorm:"foreignKey:UserID"`
+}
+
+// Role grants a set of permissions to the users holding it. System roles
+// ship with the application and cannot be deleted.
+type Role struct {
+	gorm.Model
+	Name        string       `gorm:"unique;not null;type:varchar(50)"`
+	Description string       `gorm:"type:varchar(255)"`
+	IsSystem    bool         `gorm:"default:false"`
+	Permissions []Permission `gorm:"many2many:role_permissions"`
+}
+
+// Permission is one action a role can be allowed, such as events:create.
+// The set of permissions is fixed by the application.
+type Permission struct {
+	gorm.Model
+	Name        string `gorm:"unique;not null;type:varchar(100)"`
+	Description string `gorm:"type:varchar(255)"`
+}
+
+// UserToken is a single-use token mailed to a user to verify their email
+// address or reset their password. Only its hash is stored.
+type UserToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	Purpose   string    `gorm:"type:enum('verify_email','reset_password');not null"`
+	TokenHash string    `gorm:"unique;not null;type:char(64)"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+}
+
+// Setting is an application setting adjustable by admins at runtime.
+type Setting struct {
+	gorm.Model
+	Key   string `gorm:"unique;not null;type:varchar(100)"`
+	Value string `gorm:"type:varchar(255);not null"`
+}
+
+// RefreshToken trades for a new access token once. Each refresh rotates it
+// to a new token of the same family; presenting a rotated token again means
+// it was stolen, and the whole family is revoked.
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"unique;not null;type:char(64)"`
+	FamilyID  string    `gorm:"not null;type:char(32);index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
+
+// RevokedToken keeps a revoked access token out until it expires anyway.
+type RevokedToken struct {
+	gorm.Model
+	JTI       string    `gorm:"unique;not null;type:varchar(64)"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
 }
 
 type Event struct {
@@ -30,24 +98,341 @@ type Event struct {
 	SoldTickets int     `gorm:"default:0;check:sold_tickets >= 0"`
 	CreatedBy   uint    `gorm:"not null"`
 	IsActive    bool    `gorm:"default:true"`
+	VenueID     *uint   `gorm:"index"`
+	HallID      *uint   `gorm:"index"`
+	SeriesID    *uint   `gorm:"index"`
+
+	User    User       `gorm:"foreignKey:CreatedBy"`
+	Tickets []Ticket   `gorm:"foreignKey:EventID"`
+	Venue   *Venue     `gorm:"foreignKey:VenueID"`
+	Hall    *VenueHall `gorm:"foreignKey:HallID"`
+}
+
+// EventSeries generates recurring events from an RRULE. Every occurrence is
+// a regular Event with its own capacity and sales; the series keeps the
+// template its occurrences were generated from.
+type EventSeries struct {
+	gorm.Model
+	Title           string    `gorm:"unique;not null;type:varchar(200)"`
+	Description     string    `gorm:"type:text"`
+	Location        string    `gorm:"type:varchar(255)"`
+	Category        string    `gorm:"type:varchar(100)"`
+	RRule           string    `gorm:"not null;type:varchar(500)"`
+	StartsAt        time.Time `gorm:"not null"`
+	DurationMinutes int       `gorm:"not null;check:duration_minutes > 0"`
+	Capacity        int       `gorm:"not null;check:capacity > 0"`
+	Price           float64   `gorm:"type:decimal(10,2);not null;check:price >= 0"`
+	VenueID         *uint     `gorm:"index"`
+	HallID          *uint
+	CreatedBy       uint `gorm:"not null"`
+
+	Exceptions []EventSeriesException `gorm:"foreignKey:SeriesID"`
+	Events     []Event                `gorm:"foreignKey:SeriesID"`
+}
+
+// EventSeriesException removes the occurrence of a series on Date.
+type EventSeriesException struct {
+	gorm.Model
+	SeriesID uint      `gorm:"not null;uniqueIndex:idx_series_exceptions_series_date"`
+	Date     time.Time `gorm:"type:date;not null;uniqueIndex:idx_series_exceptions_series_date"`
+	Reason   string    `gorm:"type:text"`
+}
+
+// Session is a slot of an event's agenda. Registered counts the attendees
+// signed up for it and never exceeds Capacity.
+type Session struct {
+	gorm.Model
+	EventID     uint      `gorm:"not null;index"`
+	Title       string    `gorm:"not null;type:varchar(255)"`
+	Description string    `gorm:"type:text"`
+	Room        string    `gorm:"type:varchar(100)"`
+	StartTime   time.Time `gorm:"not null;index"`
+	EndTime     time.Time `gorm:"not null"`
+	Capacity    int       `gorm:"not null;check:capacity > 0"`
+	Registered  int       `gorm:"default:0;check:registered >= 0"`
+
+	Event    Event     `gorm:"foreignKey:EventID"`
+	Speakers []Speaker `gorm:"many2many:session_speakers"`
+}
+
+type Speaker struct {
+	gorm.Model
+	EventID  uint   `gorm:"not null;index"`
+	Name     string `gorm:"not null;type:varchar(255)"`
+	Headline string `gorm:"type:varchar(255)"`
+	Company  string `gorm:"type:varchar(255)"`
+	Bio      string `gorm:"type:text"`
+	PhotoURL string `gorm:"type:varchar(500)"`
+
+	Sessions []Session `gorm:"many2many:session_speakers"`
+}
+
+// SessionRegistration signs an attendee up for a session. Registrations are
+// deleted outright when withdrawn so the attendee can sign up again.
+type SessionRegistration struct {
+	gorm.Model
+	SessionID uint `gorm:"not null;uniqueIndex:idx_session_registrations_session_user"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_session_registrations_session_user;index"`
+
+	Session Session `gorm:"foreignKey:SessionID"`
+	User    User    `gorm:"foreignKey:UserID"`
+}
+
+type Venue struct {
+	gorm.Model
+	Name        string   `gorm:"not null;type:varchar(255)"`
+	Address     string   `gorm:"not null;type:varchar(255)"`
+	City        string   `gorm:"not null;type:varchar(100);index"`
+	Latitude    *float64 `gorm:"type:decimal(9,6)"`
+	Longitude   *float64 `gorm:"type:decimal(9,6)"`
+	Timezone    string   `gorm:"not null;type:varchar(64)"`
+	MaxCapacity int      `gorm:"not null;check:max_capacity > 0"`
+
+	Halls []VenueHall `gorm:"foreignKey:VenueID"`
+}
+
+// SeatSection groups the seats of a seat map. A section belongs either to a
+// venue, as a template for its events, or to a single event, where its
+// seats are sold. Sections without a price sell at the ticket price; a
+// section price replaces the tier price for seats in that section.
+type SeatSection struct {
+	gorm.Model
+	VenueID *uint    `gorm:"index"`
+	EventID *uint    `gorm:"index"`
+	Name    string   `gorm:"not null;type:varchar(100)"`
+	Price   *float64 `gorm:"type:decimal(10,2)"`
+
+	Seats []Seat `gorm:"foreignKey:SectionID"`
+}
+
+// Seat is one seat of a section. Status, HoldID and TicketID only apply to
+// event seats.
+type Seat struct {
+	gorm.Model
+	SectionID uint   `gorm:"not null;uniqueIndex:idx_seats_section_row_number"`
+	EventID   *uint  `gorm:"index"`
+	Row       string `gorm:"not null;type:varchar(10);uniqueIndex:idx_seats_section_row_number"`
+	Number    int    `gorm:"not null;uniqueIndex:idx_seats_section_row_number"`
+	Status    string `gorm:"type:enum('available','held','booked');default:'available';index"`
+	HoldID    *uint  `gorm:"index"`
+	TicketID  *uint  `gorm:"index"`
+
+	Section SeatSection `gorm:"foreignKey:SectionID"`
+}
+
+// VenueHall is a separately bookable room of a venue. Events held in
+// different halls of the same venue may overlap.
+type VenueHall struct {
+	gorm.Model
+	VenueID  uint   `gorm:"not null;uniqueIndex:idx_venue_halls_venue_name"`
+	Name     string `gorm:"not null;type:varchar(100);uniqueIndex:idx_venue_halls_venue_name"`
+	Capacity int    `gorm:"not null;check:capacity > 0"`
 
-	User    User     `gorm:"foreignKey:CreatedBy"`
-	Tickets []Ticket `gorm:"foreignKey:EventID"`
+	Venue Venue `gorm:"foreignKey:VenueID"`
 }
 
 type Ticket struct {
 	gorm.Model
-	UserID       uint
-	EventID      uint
-	Quantity     int       `gorm:"not null;default:1;check:quantity > 0"`
-	UnitPrice    float64   `gorm:"type:decimal(10,2);not null"`
-	TotalPrice   float64   `gorm:"type:decimal(10,2);not null"`
-	Status       string    `gorm:"type:enum('booked','cancelled','used');default:'booked'"`
-	BookingCode  string    `gorm:"unique;not null;type:varchar(20)"`
-	PurchaseDate time.Time `gorm:"not null"`
-	CancelledAt  *time.Time
-	CancelReason string `gorm:"type:text"`
+	UserID         uint
+	EventID        uint
+	Quantity       int       `gorm:"not null;default:1;check:quantity > 0"`
+	UnitPrice      float64   `gorm:"type:decimal(10,2);not null"`
+	TotalPrice     float64   `gorm:"type:decimal(10,2);not null"`
+	Status         string    `gorm:"type:enum('pending','booked','cancelled','used','no_show');default:'pending'"`
+	BookingCode    string    `gorm:"unique;not null;type:varchar(20)"`
+	PurchaseDate   time.Time `gorm:"not null"`
+	CancelledAt    *time.Time
+	CancelReason   string `gorm:"type:text"`
+	TierID         *uint  `gorm:"index"`
+	CheckedInCount int    `gorm:"default:0;check:checked_in_count >= 0"`
+	CheckedInAt    *time.Time
+	CheckedInBy    *uint
+	PromoCodeID    *uint   `gorm:"index"`
+	DiscountAmount float64 `gorm:"type:decimal(10,2);default:0"`
+
+	User      User        `gorm:"foreignKey:UserID"`
+	Event     Event       `gorm:"foreignKey:EventID"`
+	Tier      *TicketTier `gorm:"foreignKey:TierID"`
+	Refund    *Refund     `gorm:"foreignKey:TicketID"`
+	PromoCode *PromoCode  `gorm:"foreignKey:PromoCodeID"`
+	Seats     []Seat      `gorm:"foreignKey:TicketID"`
+}
+
+type TicketHold struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	EventID   uint      `gorm:"not null;index"`
+	Quantity  int       `gorm:"not null;check:quantity > 0"`
+	Token     string    `gorm:"unique;not null;type:varchar(64)"`
+	Status    string    `gorm:"type:enum('active','confirmed','released','expired');default:'active';index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	TicketID  *uint
+	TierID    *uint
 
 	User  User  `gorm:"foreignKey:UserID"`
 	Event Event `gorm:"foreignKey:EventID"`
 }
+
+type TicketTier struct {
+	gorm.Model
+	EventID    uint    `gorm:"not null;uniqueIndex:idx_ticket_tiers_event_name"`
+	Name       string  `gorm:"not null;type:varchar(100);uniqueIndex:idx_ticket_tiers_event_name"`
+	Price      float64 `gorm:"type:decimal(10,2);not null;check:price >= 0"`
+	Capacity   int     `gorm:"not null;check:capacity > 0"`
+	Sold       int     `gorm:"default:0;check:sold >= 0"`
+	SalesStart *time.Time
+	SalesEnd   *time.Time
+
+	Event Event `gorm:"foreignKey:EventID"`
+}
+
+type TicketCheckIn struct {
+	gorm.Model
+	TicketID  uint      `gorm:"not null;index"`
+	Count     int       `gorm:"not null;check:count > 0"`
+	ScannedBy uint      `gorm:"not null"`
+	ScannedAt time.Time `gorm:"not null"`
+
+	Ticket Ticket `gorm:"foreignKey:TicketID"`
+}
+
+type WaitlistEntry struct {
+	gorm.Model
+	EventID   uint `gorm:"not null;index"`
+	UserID    uint `gorm:"not null;index"`
+	TierID    *uint
+	Quantity  int    `gorm:"not null;check:quantity > 0"`
+	Status    string `gorm:"type:enum('waiting','offered','fulfilled','expired','declined','cancelled');default:'waiting';index"`
+	HoldID    *uint  `gorm:"index"`
+	OfferedAt *time.Time
+
+	User  User        `gorm:"foreignKey:UserID"`
+	Event Event       `gorm:"foreignKey:EventID"`
+	Hold  *TicketHold `gorm:"foreignKey:HoldID"`
+}
+
+type Order struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	TicketID  uint      `gorm:"not null;uniqueIndex"`
+	Amount    float64   `gorm:"type:decimal(10,2);not null"`
+	Status    string    `gorm:"type:enum('pending','paid','failed','expired','refunded','partially_refunded');default:'pending';index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	PaidAt    *time.Time
+
+	User     User      `gorm:"foreignKey:UserID"`
+	Ticket   Ticket    `gorm:"foreignKey:TicketID"`
+	Payments []Payment `gorm:"foreignKey:OrderID"`
+}
+
+type Payment struct {
+	gorm.Model
+	OrderID    uint    `gorm:"not null;index"`
+	Gateway    string  `gorm:"type:varchar(50);not null"`
+	Reference  string  `gorm:"unique;not null;type:varchar(64)"`
+	GatewayRef string  `gorm:"type:varchar(100)"`
+	Amount     float64 `gorm:"type:decimal(10,2);not null"`
+	Status     string  `gorm:"type:enum('pending','paid','failed','expired','refunded','partially_refunded');default:'pending'"`
+	PaymentURL string  `gorm:"type:varchar(255)"`
+	Callback   string  `gorm:"type:text"`
+	PaidAt     *time.Time
+}
+
+// RefundPolicyRule grants Percentage of the ticket price back when a ticket
+// is cancelled at least MinHoursBefore hours before the event starts.
+type RefundPolicyRule struct {
+	gorm.Model
+	EventID        uint    `gorm:"not null;uniqueIndex:idx_event_min_hours"`
+	MinHoursBefore int     `gorm:"not null;uniqueIndex:idx_event_min_hours;check:min_hours_before >= 0"`
+	Percentage     float64 `gorm:"type:decimal(5,2);not null;check:percentage >= 0 AND percentage <= 100"`
+}
+
+type Refund struct {
+	gorm.Model
+	TicketID    uint    `gorm:"not null;uniqueIndex"`
+	OrderID     uint    `gorm:"not null;index"`
+	UserID      uint    `gorm:"not null;index"`
+	Amount      float64 `gorm:"type:decimal(10,2);not null"`
+	Percentage  float64 `gorm:"type:decimal(5,2);not null"`
+	Status      string  `gorm:"type:enum('pending','processed','failed','declined');default:'pending';index"`
+	Reason      string  `gorm:"type:text"`
+	GatewayRef  string  `gorm:"type:varchar(100)"`
+	ProcessedAt *time.Time
+}
+
+// PromoCode discounts bookings either by a percentage of the subtotal or by
+// a fixed amount. Codes without an EventID apply to every event. Zero limits
+// mean unlimited.
+type PromoCode struct {
+	gorm.Model
+	Code           string  `gorm:"unique;not null;type:varchar(50)"`
+	Description    string  `gorm:"type:text"`
+	DiscountType   string  `gorm:"type:enum('percentage','fixed');not null"`
+	DiscountValue  float64 `gorm:"type:decimal(10,2);not null;check:discount_value > 0"`
+	EventID        *uint   `gorm:"index"`
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	MaxRedemptions int  `gorm:"default:0;check:max_redemptions >= 0"`
+	PerUserLimit   int  `gorm:"default:0;check:per_user_limit >= 0"`
+	MinQuantity    int  `gorm:"default:1;check:min_quantity >= 1"`
+	Redemptions    int  `gorm:"default:0;check:redemptions >= 0"`
+	IsActive       bool `gorm:"default:true"`
+
+	Event *Event `gorm:"foreignKey:EventID"`
+}
+
+type PromoRedemption struct {
+	gorm.Model
+	PromoCodeID uint    `gorm:"not null;index"`
+	UserID      uint    `gorm:"not null;index"`
+	TicketID    uint    `gorm:"not null;uniqueIndex"`
+	Discount    float64 `gorm:"type:decimal(10,2);not null"`
+	Status      string  `gorm:"type:enum('redeemed','released');default:'redeemed';index"`
+
+	User   User   `gorm:"foreignKey:UserID"`
+	Ticket Ticket `gorm:"foreignKey:TicketID"`
+}
+
+// EventStatusHistory records every status change of an event. ActorID is
+// nil for changes made by the system.
+type EventStatusHistory struct {
+	gorm.Model
+	EventID    uint   `gorm:"not null;index"`
+	FromStatus string `gorm:"type:varchar(20);not null"`
+	ToStatus   string `gorm:"type:varchar(20);not null"`
+	ActorID    *uint
+	Reason     string `gorm:"type:text"`
+
+	Actor *User `gorm:"foreignKey:ActorID"`
+}
+
+// EventCancellationJob tracks the cancellation of an event's tickets. The
+// tickets are processed in batches in ticket ID order; LastTicketID is the
+// cursor that lets an interrupted job carry on where it stopped.
+type EventCancellationJob struct {
+	gorm.Model
+	EventID          uint    `gorm:"not null;uniqueIndex"`
+	RequestedBy      uint    `gorm:"not null"`
+	Reason           string  `gorm:"type:text"`
+	Status           string  `gorm:"type:enum('pending','running','completed');default:'pending';index"`
+	TotalTickets     int64   `gorm:"default:0"`
+	ProcessedTickets int64   `gorm:"default:0"`
+	RefundedAmount   float64 `gorm:"type:decimal(12,2);default:0"`
+	LastTicketID     uint    `gorm:"default:0"`
+	LastError        string  `gorm:"type:text"`
+	StartedAt        *time.Time
+	CompletedAt      *time.Time
+}
+
+type Notification struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	Type     string `gorm:"type:varchar(50);not null"`
+	Subject  string `gorm:"type:varchar(255);not null"`
+	Body     string `gorm:"type:text"`
+	Status   string `gorm:"type:enum('queued','sent','failed');default:'queued';index"`
+	EventID  *uint  `gorm:"index"`
+	TicketID *uint
+	SentAt   *time.Time
+	Attempts int `gorm:"default:0"`
+}
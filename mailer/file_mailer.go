@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer writes every message as an .eml file into a directory instead
+// of sending it, so tests and local setups can read the mail back. It is the
+// default driver; files are readable by the owner only since messages carry
+// account tokens.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir string, from string) *FileMailer {
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405"), hex.EncodeToString(b))
+	if err := os.WriteFile(filepath.Join(m.dir, name), buildMessage(m.from, msg), 0o600); err != nil {
+		return fmt.Errorf("failed to write mail to %s: %w", msg.To, err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package mailer
+
+// Mail drivers selectable through configuration
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+	DriverLog  = "log"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers plain-text email. The SMTP mailer talks to a real server;
+// the file and log mailers keep everything local so mail flows can be
+// exercised offline.
+type Mailer interface {
+	Send(msg Message) error
+}
@@ -5,6 +5,8 @@ import (
 	"case_study_api/container"
 	"case_study_api/middleware"
 	"case_study_api/routes"
+	"case_study_api/workers"
+	"context"
 	"log"
 
 	"github.com/gin-gonic/gin"
@@ -18,6 +20,13 @@ func main() {
 	// Initialize dependency injection container
 	appContainer := container.NewContainer(db)
 
+	// Start background workers
+	workers.NewHoldSweeper(appContainer.TicketService, cfg.HoldSweepInterval).Start(context.Background())
+	workers.NewOrderExpirer(appContainer.TicketService, cfg.HoldSweepInterval).Start(context.Background())
+	workers.NewEventLifecycleScheduler(appContainer.LifecycleService, cfg.LifecycleInterval).Start(context.Background())
+	workers.NewEventCancellationWorker(appContainer.CancellationService, cfg.LifecycleInterval).Start(context.Background())
+	workers.NewTokenCleaner(appContainer.AuthService, cfg.TokenCleanupInterval).Start(context.Background())
+
 	r := gin.New()
 	r.Use(
 		gin.Logger(),
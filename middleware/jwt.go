@@ -1,16 +1,23 @@
 package middleware
 
 import (
-	"case_study_api/config"
 	"case_study_api/utils"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 )
 
-func JWTAuth() gin.HandlerFunc {
+// TokenRevocationChecker reports whether an access token was revoked
+// before its expiry, e.g. on logout, or its user was suspended since it was
+// issued. GetUserAccess also returns the user's current role, which wins
+// over the role claim of the token.
+type TokenRevocationChecker interface {
+	IsTokenRevoked(tokenID string) (bool, error)
+	GetUserAccess(userID uint) (role string, suspended bool, err error)
+}
+
+func JWTAuth(revocations TokenRevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
@@ -19,42 +26,62 @@ func JWTAuth() gin.HandlerFunc {
 		}
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(config.App.JWTSecret), nil
-		})
+		claims, err := utils.ParseJWT(tokenStr)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.BuildErrorResponse(err.Error()))
+			return
+		}
 
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.BuildErrorResponse("invalid or expired JWT"))
+		revoked, err := revocations.IsTokenRevoked(claims.ID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to verify JWT"))
+			return
+		}
+		if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.BuildErrorResponse("JWT has been revoked"))
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, utils.BuildErrorResponse("invalid JWT claims"))
+		role, suspended, err := revocations.GetUserAccess(claims.UserID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to verify JWT"))
+			return
+		}
+		if suspended {
+			c.AbortWithStatusJSON(http.StatusForbidden, utils.BuildErrorResponse("account is suspended"))
 			return
 		}
 
-		c.Set("user_id", uint(claims["user_id"].(float64)))
-		c.Set("user_role", claims["role"].(string))
+		c.Set("user_id", claims.UserID)
+		// The role may have changed since the token was issued
+		c.Set("user_role", role)
+		c.Set("token_id", claims.ID)
+		c.Set("token_expires_at", claims.ExpiresAt.Time)
 		c.Next()
 	}
 }
 
-func RoleAuth(requiredRoles ...string) gin.HandlerFunc {
+// EmailVerificationChecker reports whether a user has to verify their email
+// address before continuing.
+type EmailVerificationChecker interface {
+	MustVerifyEmail(userID uint) (bool, error)
+}
+
+// RequireVerifiedEmail blocks users whose email address is not verified
+// yet, when the application requires verification.
+func RequireVerifiedEmail(checker EmailVerificationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		roleVal, exists := c.Get("user_role")
-		if !exists {
-			c.AbortWithStatusJSON(http.StatusForbidden, utils.BuildErrorResponse("role not found in token"))
+		userID := c.MustGet("user_id").(uint)
+
+		mustVerify, err := checker.MustVerifyEmail(userID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to check email verification"))
 			return
 		}
-
-		userRole := roleVal.(string)
-		for _, role := range requiredRoles {
-			if role == userRole {
-				c.Next()
-				return
-			}
+		if mustVerify {
+			c.AbortWithStatusJSON(http.StatusForbidden, utils.BuildErrorResponse("please verify your email address before booking"))
+			return
 		}
-		c.AbortWithStatusJSON(http.StatusForbidden, utils.BuildErrorResponse("access forbidden for role: "+userRole))
+		c.Next()
 	}
 }
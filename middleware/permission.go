@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"case_study_api/constants"
+	"case_study_api/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionProvider looks up the permissions granted to a role.
+type PermissionProvider interface {
+	GetRolePermissions(role string) ([]string, error)
+}
+
+// OwnerResolver returns the ID of the user owning the resource a request
+// targets.
+type OwnerResolver func(c *gin.Context) (uint, error)
+
+// LoadPermissions reads the permissions of the caller's role for the
+// permission checks further down the chain. It runs after JWTAuth, which
+// sets the role from the database, and since it reads them on every
+// request, role changes and changes to a role apply at once.
+func LoadPermissions(provider PermissionProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.MustGet("user_role").(string)
+
+		names, err := provider.GetRolePermissions(role)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, utils.BuildErrorResponse("failed to load permissions"))
+			return
+		}
+
+		permissions := make(map[string]bool, len(names))
+		for _, name := range names {
+			permissions[name] = true
+		}
+		c.Set("permissions", permissions)
+		c.Next()
+	}
+}
+
+// HasPermission reports whether the caller's role grants permission.
+func HasPermission(c *gin.Context, permission string) bool {
+	permissions, exists := c.Get("permissions")
+	if !exists {
+		return false
+	}
+	return permissions.(map[string]bool)[permission]
+}
+
+// RequirePermission allows the request only when the caller's role grants
+// every given permission.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, permission := range permissions {
+			if !HasPermission(c, permission) {
+				c.AbortWithStatusJSON(http.StatusForbidden, utils.BuildErrorResponse("missing permission: "+permission))
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequirePermissionOrOwn allows the request when the caller's role grants
+// permission, or its ":own" variant and the caller owns the resource.
+func RequirePermissionOrOwn(permission string, owner OwnerResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if HasPermission(c, permission) {
+			c.Next()
+			return
+		}
+		if !HasPermission(c, permission+constants.OwnPermissionSuffix) {
+			c.AbortWithStatusJSON(http.StatusForbidden, utils.BuildErrorResponse("missing permission: "+permission))
+			return
+		}
+
+		ownerID, err := owner(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, utils.BuildErrorResponse(err.Error()))
+			return
+		}
+		if ownerID != c.MustGet("user_id").(uint) {
+			c.AbortWithStatusJSON(http.StatusForbidden, utils.BuildErrorResponse("you can only manage your own resources"))
+			return
+		}
+		c.Next()
+	}
+}
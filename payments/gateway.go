@@ -0,0 +1,63 @@
+package payments
+
+import "errors"
+
+// Charge and callback statuses reported by gateways
+const (
+	StatusPending = "pending"
+	StatusPaid    = "paid"
+	StatusFailed  = "failed"
+)
+
+var ErrInvalidSignature = errors.New("invalid callback signature")
+
+type ChargeRequest struct {
+	// Reference is the merchant-side payment reference; gateways echo it
+	// back in their callbacks.
+	Reference   string
+	Amount      float64
+	Description string
+	// Scenario lets test gateways simulate an outcome. Real gateways
+	// ignore it.
+	Scenario string
+}
+
+type Charge struct {
+	GatewayRef string
+	PaymentURL string
+	Status     string
+}
+
+type RefundRequest struct {
+	// Reference and GatewayRef identify the original charge.
+	Reference  string
+	GatewayRef string
+	Amount     float64
+	Reason     string
+}
+
+type RefundResult struct {
+	GatewayRef string
+}
+
+type CallbackEvent struct {
+	Reference  string  `json:"reference"`
+	GatewayRef string  `json:"gateway_ref"`
+	Status     string  `json:"status"`
+	Amount     float64 `json:"amount"`
+}
+
+// Gateway is implemented by every payment provider. Providers confirm the
+// outcome of a charge asynchronously by calling the webhook endpoint with a
+// signed payload.
+type Gateway interface {
+	Name() string
+	CreateCharge(req ChargeRequest) (*Charge, error)
+	// Refund returns part or all of a paid charge to the customer.
+	Refund(req RefundRequest) (*RefundResult, error)
+	// ParseCallback verifies the signature of a webhook payload and decodes
+	// it. It returns ErrInvalidSignature for forged or tampered payloads.
+	ParseCallback(payload []byte, signature string) (*CallbackEvent, error)
+	// SignatureHeader names the HTTP header carrying the callback signature.
+	SignatureHeader() string
+}
@@ -0,0 +1,142 @@
+package payments
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+)
+
+// GatewayMock names the mock gateway in configuration and callback URLs
+const GatewayMock = "mock"
+
+// Mock gateway scenarios, selected per charge through ChargeRequest.Scenario
+const (
+	MockScenarioSuccess = "success"
+	MockScenarioFailure = "failure"
+	MockScenarioDelayed = "delayed"
+	MockScenarioNone    = "none"
+)
+
+// MockGateway is an offline payment provider. Instead of talking to a real
+// processor it posts a signed callback to the application's own webhook
+// endpoint after a short delay, so the full payment flow can be exercised
+// locally.
+type MockGateway struct {
+	secret       string
+	callbackURL  string
+	delay        time.Duration
+	delayedDelay time.Duration
+	client       *http.Client
+}
+
+func NewMockGateway(secret string, callbackURL string, delay time.Duration, delayedDelay time.Duration) *MockGateway {
+	return &MockGateway{
+		secret:       secret,
+		callbackURL:  callbackURL,
+		delay:        delay,
+		delayedDelay: delayedDelay,
+		client:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (g *MockGateway) Name() string {
+	return GatewayMock
+}
+
+func (g *MockGateway) SignatureHeader() string {
+	return "X-Mock-Signature"
+}
+
+// CreateCharge accepts every charge and schedules its callback according to
+// the requested scenario: success and failure report after the normal delay,
+// delayed reports success after the long delay and none never reports, which
+// leaves the order to expire.
+func (g *MockGateway) CreateCharge(req ChargeRequest) (*Charge, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	gatewayRef := "MOCK-" + hex.EncodeToString(b)
+
+	event := CallbackEvent{
+		Reference:  req.Reference,
+		GatewayRef: gatewayRef,
+		Status:     StatusPaid,
+		Amount:     req.Amount,
+	}
+
+	switch req.Scenario {
+	case MockScenarioFailure:
+		event.Status = StatusFailed
+		g.scheduleCallback(event, g.delay)
+	case MockScenarioDelayed:
+		g.scheduleCallback(event, g.delayedDelay)
+	case MockScenarioNone:
+	default:
+		g.scheduleCallback(event, g.delay)
+	}
+
+	return &Charge{
+		GatewayRef: gatewayRef,
+		PaymentURL: "mock://pay/" + gatewayRef,
+		Status:     StatusPending,
+	}, nil
+}
+
+// Refund settles immediately; the mock never rejects a refund.
+func (g *MockGateway) Refund(req RefundRequest) (*RefundResult, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return &RefundResult{GatewayRef: "MOCKRF-" + hex.EncodeToString(b)}, nil
+}
+
+func (g *MockGateway) ParseCallback(payload []byte, signature string) (*CallbackEvent, error) {
+	if !hmac.Equal([]byte(signature), []byte(g.sign(payload))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event CallbackEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (g *MockGateway) scheduleCallback(event CallbackEvent, delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("Mock gateway failed to encode callback for %s: %v", event.Reference, err)
+			return
+		}
+
+		req, err := http.NewRequest(http.MethodPost, g.callbackURL, bytes.NewReader(payload))
+		if err != nil {
+			log.Printf("Mock gateway failed to build callback for %s: %v", event.Reference, err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(g.SignatureHeader(), g.sign(payload))
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			log.Printf("Mock gateway callback for %s failed: %v", event.Reference, err)
+			return
+		}
+		resp.Body.Close()
+	})
+}
+
+func (g *MockGateway) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(g.secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
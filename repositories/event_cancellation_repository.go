@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventCancellationRepository interface {
+	WithTx(tx *gorm.DB) EventCancellationRepository
+	Create(job *entities.EventCancellationJob) error
+	Update(job *entities.EventCancellationJob) error
+	GetByEventID(eventID uint) (*entities.EventCancellationJob, error)
+	GetByIDForUpdate(id uint) (*entities.EventCancellationJob, error)
+	GetUnfinishedIDs() ([]uint, error)
+}
+
+type eventCancellationRepository struct {
+	db *gorm.DB
+}
+
+func NewEventCancellationRepository(db *gorm.DB) EventCancellationRepository {
+	return &eventCancellationRepository{db: db}
+}
+
+func (r *eventCancellationRepository) WithTx(tx *gorm.DB) EventCancellationRepository {
+	return &eventCancellationRepository{db: tx}
+}
+
+func (r *eventCancellationRepository) Create(job *entities.EventCancellationJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *eventCancellationRepository) Update(job *entities.EventCancellationJob) error {
+	return r.db.Save(job).Error
+}
+
+func (r *eventCancellationRepository) GetByEventID(eventID uint) (*entities.EventCancellationJob, error) {
+	var job entities.EventCancellationJob
+	err := r.db.Where("event_id = ?", eventID).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetByIDForUpdate loads a job and locks its row, so only one worker
+// processes a batch of it at a time.
+func (r *eventCancellationRepository) GetByIDForUpdate(id uint) (*entities.EventCancellationJob, error) {
+	var job entities.EventCancellationJob
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *eventCancellationRepository) GetUnfinishedIDs() ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.EventCancellationJob{}).
+		Where("status <> ?", constants.JobStatusCompleted).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
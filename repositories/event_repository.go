@@ -1,18 +1,65 @@
 package repositories
 
 import (
+	"case_study_api/constants"
 	"case_study_api/entities"
+	"errors"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrInsufficientCapacity is returned when a conditional sold_tickets update
+// would push an event past its capacity.
+var ErrInsufficientCapacity = errors.New("insufficient event capacity")
+
+// eventSortColumns maps the sort keys accepted by the API to columns. Only
+// these columns may ever reach ORDER BY.
+var eventSortColumns = map[string]string{
+	"date":         "date",
+	"price":        "price",
+	"sold_tickets": "sold_tickets",
+	"created_at":   "created_at",
+}
+
+// IsValidEventSort reports whether sort is a key that events can be sorted by.
+func IsValidEventSort(sort string) bool {
+	_, ok := eventSortColumns[sort]
+	return ok
+}
+
+// EventFilter narrows down an event listing. Zero values mean no filter.
+type EventFilter struct {
+	Category        string
+	Status          string
+	DateFrom        *time.Time
+	DateTo          *time.Time
+	MinPrice        *float64
+	MaxPrice        *float64
+	Location        string
+	HasAvailability bool
+	Search          string
+	Sort            string
+	Order           string
+}
+
 type EventRepository interface {
+	WithTx(tx *gorm.DB) EventRepository
 	GetAll() ([]entities.Event, error)
-	GetAllPaginated(offset, limit int) ([]entities.Event, int64, error)
+	GetAllPaginated(filter EventFilter, offset, limit int) ([]entities.Event, int64, error)
+	GetByCursor(filter EventFilter, keyset *Keyset, limit int) ([]entities.Event, bool, error)
 	GetByID(id uint) (*entities.Event, error)
+	GetByIDForUpdate(id uint) (*entities.Event, error)
 	Create(event *entities.Event) error
 	Update(event *entities.Event) error
 	Delete(event *entities.Event) error
+	IncrementSoldTickets(id uint, quantity int) error
+	DecrementSoldTickets(id uint, quantity int) error
+	GetIDsDueToStart(now time.Time) ([]uint, error)
+	GetIDsDueToComplete(now time.Time) ([]uint, error)
+	FindVenueConflict(venueID uint, hallID *uint, start, end time.Time, excludeID uint) (*entities.Event, error)
 }
 
 type eventRepository struct {
@@ -23,26 +70,104 @@ func NewEventRepository(db *gorm.DB) EventRepository {
 	return &eventRepository{db: db}
 }
 
+func (r *eventRepository) WithTx(tx *gorm.DB) EventRepository {
+	return &eventRepository{db: tx}
+}
+
 func (r *eventRepository) GetAll() ([]entities.Event, error) {
 	var events []entities.Event
 	err := r.db.Find(&events).Error
 	return events, err
 }
 
-func (r *eventRepository) GetAllPaginated(offset, limit int) ([]entities.Event, int64, error) {
+func (r *eventRepository) GetAllPaginated(filter EventFilter, offset, limit int) ([]entities.Event, int64, error) {
 	var events []entities.Event
 	var total int64
 
-	// Get total count
-	if err := r.db.Model(&entities.Event{}).Count(&total).Error; err != nil {
+	// Get total count of the filtered events
+	if err := r.filtered(filter).Model(&entities.Event{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	err := r.db.Offset(offset).Limit(limit).Find(&events).Error
+	column, ok := eventSortColumns[filter.Sort]
+	if !ok {
+		column = "id"
+	}
+	order := "ASC"
+	if filter.Order == constants.SortOrderDesc {
+		order = "DESC"
+	}
+
+	err := r.filtered(filter).
+		Order(column + " " + order).
+		Order("id " + order).
+		Offset(offset).Limit(limit).
+		Find(&events).Error
 	return events, total, err
 }
 
+// GetByCursor returns the page of filtered events following keyset, along
+// with whether more events exist in the direction the page was read.
+func (r *eventRepository) GetByCursor(filter EventFilter, keyset *Keyset, limit int) ([]entities.Event, bool, error) {
+	var events []entities.Event
+
+	column, ok := eventSortColumns[filter.Sort]
+	if !ok {
+		column = "id"
+	}
+
+	query := keysetPage(r.filtered(filter), column, filter.Order == constants.SortOrderDesc, keyset, limit)
+	if err := query.Find(&events).Error; err != nil {
+		return nil, false, err
+	}
+
+	events, hasMore := trimKeysetPage(events, limit, keyset != nil && keyset.Backward)
+	return events, hasMore, nil
+}
+
+// filtered returns a query with every filter in filter applied. All values
+// are passed as bind parameters.
+func (r *eventRepository) filtered(filter EventFilter) *gorm.DB {
+	query := r.db.Model(&entities.Event{})
+
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.DateFrom != nil {
+		query = query.Where("date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("date <= ?", *filter.DateTo)
+	}
+	if filter.MinPrice != nil {
+		query = query.Where("price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		query = query.Where("price <= ?", *filter.MaxPrice)
+	}
+	if filter.Location != "" {
+		query = query.Where("location LIKE ?", "%"+escapeLike(filter.Location)+"%")
+	}
+	if filter.HasAvailability {
+		query = query.Where("sold_tickets < capacity")
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("title LIKE ? OR description LIKE ?", pattern, pattern)
+	}
+
+	return query
+}
+
+// escapeLike escapes the LIKE wildcards in s so user input matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(s)
+}
+
 func (r *eventRepository) GetByID(id uint) (*entities.Event, error) {
 	var event entities.Event
 	err := r.db.First(&event, id).Error
@@ -52,14 +177,95 @@ func (r *eventRepository) GetByID(id uint) (*entities.Event, error) {
 	return &event, nil
 }
 
+// GetByIDForUpdate loads an event and locks its row until the surrounding
+// transaction ends. It must be called on a repository bound with WithTx.
+func (r *eventRepository) GetByIDForUpdate(id uint) (*entities.Event, error) {
+	var event entities.Event
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&event, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
 func (r *eventRepository) Create(event *entities.Event) error {
 	return r.db.Create(event).Error
 }
 
+// Update saves the editable event fields. sold_tickets is deliberately left
+// out so that a stale copy of the event can never overwrite concurrent sales;
+// use IncrementSoldTickets/DecrementSoldTickets instead.
 func (r *eventRepository) Update(event *entities.Event) error {
-	return r.db.Save(event).Error
+	return r.db.Omit("SoldTickets").Save(event).Error
 }
 
 func (r *eventRepository) Delete(event *entities.Event) error {
 	return r.db.Delete(event).Error
 }
+
+// IncrementSoldTickets atomically adds quantity to sold_tickets only when the
+// result stays within capacity.
+func (r *eventRepository) IncrementSoldTickets(id uint, quantity int) error {
+	result := r.db.Model(&entities.Event{}).
+		Where("id = ? AND sold_tickets + ? <= capacity", id, quantity).
+		UpdateColumn("sold_tickets", gorm.Expr("sold_tickets + ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientCapacity
+	}
+	return nil
+}
+
+// DecrementSoldTickets atomically releases quantity back to the event,
+// never letting sold_tickets drop below zero.
+func (r *eventRepository) DecrementSoldTickets(id uint, quantity int) error {
+	return r.db.Model(&entities.Event{}).
+		Where("id = ? AND sold_tickets >= ?", id, quantity).
+		UpdateColumn("sold_tickets", gorm.Expr("sold_tickets - ?", quantity)).Error
+}
+
+// GetIDsDueToStart returns the upcoming events whose start time has passed.
+func (r *eventRepository) GetIDsDueToStart(now time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.Event{}).
+		Where("status = ? AND date <= ?", constants.EventStatusUpcoming, now).
+		Order("date ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// GetIDsDueToComplete returns the ongoing events that have ended. Events
+// without a usable end date end at their start date.
+func (r *eventRepository) GetIDsDueToComplete(now time.Time) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.Event{}).
+		Where("status = ? AND COALESCE(GREATEST(end_date, date), date) <= ?", constants.EventStatusOngoing, now).
+		Order("end_date ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// FindVenueConflict returns an event that is not cancelled and overlaps
+// [start, end] at the venue, or nil when there is none. An event without a
+// hall takes the whole venue, so it conflicts with events in any hall.
+func (r *eventRepository) FindVenueConflict(venueID uint, hallID *uint, start, end time.Time, excludeID uint) (*entities.Event, error) {
+	var events []entities.Event
+
+	query := r.db.Model(&entities.Event{}).
+		Where("venue_id = ? AND id <> ? AND status <> ?", venueID, excludeID, constants.EventStatusCancelled).
+		// Events without a duration still clash when they start together
+		Where("(date < ? AND COALESCE(GREATEST(end_date, date), date) > ?) OR date = ?", end, start, start)
+	if hallID != nil {
+		query = query.Where("hall_id = ? OR hall_id IS NULL", *hallID)
+	}
+
+	if err := query.Order("date ASC").Limit(1).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return &events[0], nil
+}
@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EventSeriesRepository interface {
+	WithTx(tx *gorm.DB) EventSeriesRepository
+	GetAll() ([]entities.EventSeries, error)
+	GetByID(id uint) (*entities.EventSeries, error)
+	GetByIDForUpdate(id uint) (*entities.EventSeries, error)
+	Create(series *entities.EventSeries) error
+	Update(series *entities.EventSeries) error
+	HasException(seriesID uint, date time.Time) (bool, error)
+	CreateException(exception *entities.EventSeriesException) error
+	GetUpcomingEventsForUpdate(seriesID uint, from time.Time) ([]entities.Event, error)
+	GetEventOnDate(seriesID uint, date time.Time) (*entities.Event, error)
+}
+
+type eventSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewEventSeriesRepository(db *gorm.DB) EventSeriesRepository {
+	return &eventSeriesRepository{db: db}
+}
+
+func (r *eventSeriesRepository) WithTx(tx *gorm.DB) EventSeriesRepository {
+	return &eventSeriesRepository{db: tx}
+}
+
+func (r *eventSeriesRepository) GetAll() ([]entities.EventSeries, error) {
+	var series []entities.EventSeries
+	err := r.db.Order("starts_at ASC").Find(&series).Error
+	return series, err
+}
+
+// GetByID loads a series with its exceptions and occurrences in date order.
+func (r *eventSeriesRepository) GetByID(id uint) (*entities.EventSeries, error) {
+	var series entities.EventSeries
+	err := r.db.
+		Preload("Exceptions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		}).
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date ASC")
+		}).
+		First(&series, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// GetByIDForUpdate loads a series and locks its row until the surrounding
+// transaction ends, so edits of its occurrences are serialized.
+func (r *eventSeriesRepository) GetByIDForUpdate(id uint) (*entities.EventSeries, error) {
+	var series entities.EventSeries
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&series, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// Create saves the series and its exceptions. Occurrences are created
+// separately so each one goes through the event checks.
+func (r *eventSeriesRepository) Create(series *entities.EventSeries) error {
+	return r.db.Omit("Events").Create(series).Error
+}
+
+// Update saves the series template only.
+func (r *eventSeriesRepository) Update(series *entities.EventSeries) error {
+	return r.db.Omit(clause.Associations).Save(series).Error
+}
+
+func (r *eventSeriesRepository) HasException(seriesID uint, date time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.EventSeriesException{}).
+		Where("series_id = ? AND date = ?", seriesID, date.Format("2006-01-02")).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *eventSeriesRepository) CreateException(exception *entities.EventSeriesException) error {
+	return r.db.Create(exception).Error
+}
+
+// GetUpcomingEventsForUpdate locks the occurrences of a series that start
+// at or after from and have not started yet.
+func (r *eventSeriesRepository) GetUpcomingEventsForUpdate(seriesID uint, from time.Time) ([]entities.Event, error) {
+	var events []entities.Event
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("series_id = ? AND date >= ? AND status = ?", seriesID, from, constants.EventStatusUpcoming).
+		Order("date ASC").
+		Find(&events).Error
+	return events, err
+}
+
+// GetEventOnDate returns the occurrence of a series held on the day of
+// date, or nil when there is none.
+func (r *eventSeriesRepository) GetEventOnDate(seriesID uint, date time.Time) (*entities.Event, error) {
+	var events []entities.Event
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("series_id = ? AND date >= ? AND date < ?", seriesID, date, date.AddDate(0, 0, 1)).
+		Limit(1).
+		Find(&events).Error
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	return &events[0], nil
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+)
+
+type EventStatusHistoryRepository interface {
+	WithTx(tx *gorm.DB) EventStatusHistoryRepository
+	Create(history *entities.EventStatusHistory) error
+	GetByEventID(eventID uint) ([]entities.EventStatusHistory, error)
+}
+
+type eventStatusHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewEventStatusHistoryRepository(db *gorm.DB) EventStatusHistoryRepository {
+	return &eventStatusHistoryRepository{db: db}
+}
+
+func (r *eventStatusHistoryRepository) WithTx(tx *gorm.DB) EventStatusHistoryRepository {
+	return &eventStatusHistoryRepository{db: tx}
+}
+
+func (r *eventStatusHistoryRepository) Create(history *entities.EventStatusHistory) error {
+	return r.db.Create(history).Error
+}
+
+func (r *eventStatusHistoryRepository) GetByEventID(eventID uint) ([]entities.EventStatusHistory, error) {
+	var history []entities.EventStatusHistory
+	err := r.db.Preload("Actor").
+		Where("event_id = ?", eventID).
+		Order("id ASC").
+		Find(&history).Error
+	return history, err
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import "gorm.io/gorm"
+
+// Keyset identifies the row a cursor page starts after. Rows are ordered by
+// a sort column and then by ID, so Value and ID together are unique.
+type Keyset struct {
+	Value    interface{}
+	ID       uint
+	Backward bool
+}
+
+// keysetPage restricts query to the rows following keyset in the given
+// order, nearest rows first, and fetches one row more than limit so callers
+// can tell whether another page exists. A nil keyset starts at the top.
+// column must never come from user input.
+func keysetPage(query *gorm.DB, column string, desc bool, keyset *Keyset, limit int) *gorm.DB {
+	// Walking backwards reads the rows in reverse order
+	reverse := desc != (keyset != nil && keyset.Backward)
+	op, dir := ">", "ASC"
+	if reverse {
+		op, dir = "<", "DESC"
+	}
+
+	if keyset != nil {
+		if column == "id" {
+			query = query.Where("id "+op+" ?", keyset.ID)
+		} else {
+			query = query.Where("("+column+" "+op+" ?) OR ("+column+" = ? AND id "+op+" ?)", keyset.Value, keyset.Value, keyset.ID)
+		}
+	}
+
+	if column != "id" {
+		query = query.Order(column + " " + dir)
+	}
+	return query.Order("id " + dir).Limit(limit + 1)
+}
+
+// trimKeysetPage drops the extra row fetched by keysetPage and puts
+// backward pages back into display order. It reports whether more rows
+// exist beyond the page in the direction it was read.
+func trimKeysetPage[T any](rows []T, limit int, backward bool) ([]T, bool) {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	return rows, hasMore
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	WithTx(tx *gorm.DB) NotificationRepository
+	Create(notification *entities.Notification) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) WithTx(tx *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: tx}
+}
+
+func (r *notificationRepository) Create(notification *entities.Notification) error {
+	return r.db.Create(notification).Error
+}
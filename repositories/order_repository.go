@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepository interface {
+	WithTx(tx *gorm.DB) OrderRepository
+	Create(order *entities.Order) error
+	Update(order *entities.Order) error
+	GetByID(id uint) (*entities.Order, error)
+	GetByIDForUpdate(id uint) (*entities.Order, error)
+	GetByTicketID(ticketID uint) (*entities.Order, error)
+	GetByUserIDPaginated(userID uint, offset, limit int) ([]entities.Order, int64, error)
+	GetExpiredPendingIDs(now time.Time, limit int) ([]uint, error)
+}
+
+type orderRepository struct {
+	db *gorm.DB
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+func (r *orderRepository) WithTx(tx *gorm.DB) OrderRepository {
+	return &orderRepository{db: tx}
+}
+
+func (r *orderRepository) Create(order *entities.Order) error {
+	return r.db.Create(order).Error
+}
+
+func (r *orderRepository) Update(order *entities.Order) error {
+	return r.db.Omit(clause.Associations).Save(order).Error
+}
+
+func (r *orderRepository) GetByID(id uint) (*entities.Order, error) {
+	var order entities.Order
+	err := r.db.Preload("Payments").Preload("Ticket.Event").First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetByIDForUpdate loads an order and locks its row until the surrounding
+// transaction ends.
+func (r *orderRepository) GetByIDForUpdate(id uint) (*entities.Order, error) {
+	var order entities.Order
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&order, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *orderRepository) GetByTicketID(ticketID uint) (*entities.Order, error) {
+	var order entities.Order
+	err := r.db.Preload("Payments").Where("ticket_id = ?", ticketID).First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *orderRepository) GetByUserIDPaginated(userID uint, offset, limit int) ([]entities.Order, int64, error) {
+	var orders []entities.Order
+	var total int64
+
+	// Get total count
+	if err := r.db.Model(&entities.Order{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.Preload("Payments").Preload("Ticket.Event").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&orders).Error
+	return orders, total, err
+}
+
+// GetExpiredPendingIDs returns the IDs of unpaid orders past their deadline.
+func (r *orderRepository) GetExpiredPendingIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.Order{}).
+		Where("status = ? AND expires_at <= ?", constants.PaymentStatusPending, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PaymentRepository interface {
+	WithTx(tx *gorm.DB) PaymentRepository
+	Create(payment *entities.Payment) error
+	GetByReference(reference string) (*entities.Payment, error)
+	UpdateCharge(id uint, gatewayRef, paymentURL string) error
+	RecordCallback(id uint, payload string) error
+	UpdateStatusByOrderID(orderID uint, fromStatus, toStatus string, paidAt *time.Time) error
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+func (r *paymentRepository) WithTx(tx *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: tx}
+}
+
+func (r *paymentRepository) Create(payment *entities.Payment) error {
+	return r.db.Create(payment).Error
+}
+
+func (r *paymentRepository) GetByReference(reference string) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := r.db.Where("reference = ?", reference).First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// UpdateCharge stores the gateway's charge details. Only these columns are
+// written so a callback that settled the payment in the meantime is kept.
+func (r *paymentRepository) UpdateCharge(id uint, gatewayRef, paymentURL string) error {
+	return r.db.Model(&entities.Payment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"gateway_ref": gatewayRef, "payment_url": paymentURL}).Error
+}
+
+// RecordCallback keeps the last raw webhook payload received for a payment.
+func (r *paymentRepository) RecordCallback(id uint, payload string) error {
+	return r.db.Model(&entities.Payment{}).Where("id = ?", id).Update("callback", payload).Error
+}
+
+// UpdateStatusByOrderID moves every payment of an order that is still in
+// fromStatus to toStatus.
+func (r *paymentRepository) UpdateStatusByOrderID(orderID uint, fromStatus, toStatus string, paidAt *time.Time) error {
+	updates := map[string]interface{}{"status": toStatus}
+	if paidAt != nil {
+		updates["paid_at"] = paidAt
+	}
+
+	return r.db.Model(&entities.Payment{}).
+		Where("order_id = ? AND status = ?", orderID, fromStatus).
+		Updates(updates).Error
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+)
+
+type PermissionRepository interface {
+	GetAll() ([]entities.Permission, error)
+	GetByNames(names []string) ([]entities.Permission, error)
+}
+
+type permissionRepository struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) PermissionRepository {
+	return &permissionRepository{db: db}
+}
+
+func (r *permissionRepository) GetAll() ([]entities.Permission, error) {
+	var permissions []entities.Permission
+	err := r.db.Order("name ASC").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *permissionRepository) GetByNames(names []string) ([]entities.Permission, error) {
+	var permissions []entities.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	err := r.db.Where("name IN ?", names).Find(&permissions).Error
+	return permissions, err
+}
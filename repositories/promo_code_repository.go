@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPromoCodeExhausted is returned when a promo code has no redemptions
+// left.
+var ErrPromoCodeExhausted = errors.New("promo code has reached its redemption limit")
+
+type PromoRedemptionSummary struct {
+	TotalRedemptions int64   `json:"total_redemptions"`
+	UniqueUsers      int64   `json:"unique_users"`
+	TotalDiscount    float64 `json:"total_discount"`
+	TicketsSold      int     `json:"tickets_sold"`
+	Revenue          float64 `json:"revenue"`
+}
+
+type PromoCodeRepository interface {
+	WithTx(tx *gorm.DB) PromoCodeRepository
+	GetAll() ([]entities.PromoCode, error)
+	GetByID(id uint) (*entities.PromoCode, error)
+	GetByCodeForUpdate(code string) (*entities.PromoCode, error)
+	Create(promo *entities.PromoCode) error
+	Update(promo *entities.PromoCode) error
+	Delete(promo *entities.PromoCode) error
+	CountTickets(id uint) (int64, error)
+	IncrementRedemptions(id uint) error
+	DecrementRedemptions(id uint) error
+	CountUserRedemptions(promoID uint, userID uint) (int64, error)
+	CreateRedemption(redemption *entities.PromoRedemption) error
+	GetRedemptionByTicketID(ticketID uint) (*entities.PromoRedemption, error)
+	UpdateRedemption(redemption *entities.PromoRedemption) error
+	GetRedemptions(promoID uint) ([]entities.PromoRedemption, error)
+	GetRedemptionSummary(promoID uint) (*PromoRedemptionSummary, error)
+}
+
+type promoCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewPromoCodeRepository(db *gorm.DB) PromoCodeRepository {
+	return &promoCodeRepository{db: db}
+}
+
+func (r *promoCodeRepository) WithTx(tx *gorm.DB) PromoCodeRepository {
+	return &promoCodeRepository{db: tx}
+}
+
+func (r *promoCodeRepository) GetAll() ([]entities.PromoCode, error) {
+	var promos []entities.PromoCode
+	err := r.db.Order("created_at DESC").Find(&promos).Error
+	return promos, err
+}
+
+func (r *promoCodeRepository) GetByID(id uint) (*entities.PromoCode, error) {
+	var promo entities.PromoCode
+	err := r.db.First(&promo, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+// GetByCodeForUpdate loads a promo code and locks its row, so per-user limits
+// are checked against a stable redemption count.
+func (r *promoCodeRepository) GetByCodeForUpdate(code string) (*entities.PromoCode, error) {
+	var promo entities.PromoCode
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&promo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+func (r *promoCodeRepository) Create(promo *entities.PromoCode) error {
+	return r.db.Create(promo).Error
+}
+
+// Update saves the editable promo fields; redemptions is only changed
+// through IncrementRedemptions/DecrementRedemptions.
+func (r *promoCodeRepository) Update(promo *entities.PromoCode) error {
+	return r.db.Omit("Redemptions", "Event").Save(promo).Error
+}
+
+// Delete hard deletes a promo code so the code can be issued again. Codes
+// that tickets point to cannot be deleted.
+func (r *promoCodeRepository) Delete(promo *entities.PromoCode) error {
+	return r.db.Unscoped().Delete(promo).Error
+}
+
+// CountTickets counts every ticket ever booked with a promo code, cancelled
+// and expired ones included.
+func (r *promoCodeRepository) CountTickets(id uint) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&entities.Ticket{}).Where("promo_code_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// IncrementRedemptions atomically claims one redemption, unless the code
+// has a limit and reached it.
+func (r *promoCodeRepository) IncrementRedemptions(id uint) error {
+	result := r.db.Model(&entities.PromoCode{}).
+		Where("id = ? AND (max_redemptions = 0 OR redemptions < max_redemptions)", id).
+		UpdateColumn("redemptions", gorm.Expr("redemptions + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPromoCodeExhausted
+	}
+	return nil
+}
+
+func (r *promoCodeRepository) DecrementRedemptions(id uint) error {
+	return r.db.Model(&entities.PromoCode{}).
+		Where("id = ? AND redemptions > 0", id).
+		UpdateColumn("redemptions", gorm.Expr("redemptions - 1")).Error
+}
+
+func (r *promoCodeRepository) CountUserRedemptions(promoID uint, userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.PromoRedemption{}).
+		Where("promo_code_id = ? AND user_id = ? AND status = ?", promoID, userID, constants.RedemptionStatusRedeemed).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *promoCodeRepository) CreateRedemption(redemption *entities.PromoRedemption) error {
+	return r.db.Create(redemption).Error
+}
+
+func (r *promoCodeRepository) GetRedemptionByTicketID(ticketID uint) (*entities.PromoRedemption, error) {
+	var redemption entities.PromoRedemption
+	err := r.db.Where("ticket_id = ?", ticketID).First(&redemption).Error
+	if err != nil {
+		return nil, err
+	}
+	return &redemption, nil
+}
+
+func (r *promoCodeRepository) UpdateRedemption(redemption *entities.PromoRedemption) error {
+	return r.db.Omit("User", "Ticket").Save(redemption).Error
+}
+
+func (r *promoCodeRepository) GetRedemptions(promoID uint) ([]entities.PromoRedemption, error) {
+	var redemptions []entities.PromoRedemption
+	err := r.db.Preload("User").Preload("Ticket").
+		Where("promo_code_id = ?", promoID).
+		Order("created_at DESC").
+		Find(&redemptions).Error
+	return redemptions, err
+}
+
+// GetRedemptionSummary totals the redemptions still counting against a
+// code. Tickets sold and revenue only include paid tickets.
+func (r *promoCodeRepository) GetRedemptionSummary(promoID uint) (*PromoRedemptionSummary, error) {
+	var result PromoRedemptionSummary
+
+	err := r.db.Table("promo_redemptions").
+		Select("COUNT(promo_redemptions.id) as total_redemptions, COUNT(DISTINCT promo_redemptions.user_id) as unique_users, COALESCE(SUM(promo_redemptions.discount), 0) as total_discount").
+		Where("promo_redemptions.promo_code_id = ? AND promo_redemptions.status = ? AND promo_redemptions.deleted_at IS NULL", promoID, constants.RedemptionStatusRedeemed).
+		Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var sales struct {
+		TicketsSold int
+		Revenue     float64
+	}
+	err = r.db.Table("promo_redemptions").
+		Select("COALESCE(SUM(tickets.quantity), 0) as tickets_sold, COALESCE(SUM(tickets.total_price), 0) as revenue").
+		Joins("JOIN tickets ON tickets.id = promo_redemptions.ticket_id").
+		Where("promo_redemptions.promo_code_id = ? AND promo_redemptions.status = ? AND promo_redemptions.deleted_at IS NULL", promoID, constants.RedemptionStatusRedeemed).
+		Where("tickets.status IN ?", []string{constants.TicketStatusBooked, constants.TicketStatusUsed}).
+		Scan(&sales).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result.TicketsSold = sales.TicketsSold
+	result.Revenue = sales.Revenue
+	return &result, nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepository interface {
+	WithTx(tx *gorm.DB) RefreshTokenRepository
+	Create(token *entities.RefreshToken) error
+	GetByHashForUpdate(hash string) (*entities.RefreshToken, error)
+	MarkUsed(id uint, usedAt time.Time) error
+	RevokeFamily(familyID string, revokedAt time.Time) error
+	RevokeAllForUser(userID uint, revokedAt time.Time) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+func (r *refreshTokenRepository) WithTx(tx *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: tx}
+}
+
+func (r *refreshTokenRepository) Create(token *entities.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHashForUpdate loads a refresh token and locks it, so two refreshes
+// racing with the same token cannot both rotate it.
+func (r *refreshTokenRepository) GetByHashForUpdate(hash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", hash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) MarkUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&entities.RefreshToken{}).Where("id = ?", id).Update("used_at", usedAt).Error
+}
+
+func (r *refreshTokenRepository) RevokeFamily(familyID string, revokedAt time.Time) error {
+	return r.db.Model(&entities.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *refreshTokenRepository) RevokeAllForUser(userID uint, revokedAt time.Time) error {
+	return r.db.Model(&entities.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
+
+// DeleteExpired hard deletes refresh tokens that can no longer be used.
+func (r *refreshTokenRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("expires_at < ?", now).Delete(&entities.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+)
+
+type RefundPolicyRepository interface {
+	WithTx(tx *gorm.DB) RefundPolicyRepository
+	GetByEventID(eventID uint) ([]entities.RefundPolicyRule, error)
+	ReplaceForEvent(eventID uint, rules []entities.RefundPolicyRule) error
+}
+
+type refundPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundPolicyRepository(db *gorm.DB) RefundPolicyRepository {
+	return &refundPolicyRepository{db: db}
+}
+
+func (r *refundPolicyRepository) WithTx(tx *gorm.DB) RefundPolicyRepository {
+	return &refundPolicyRepository{db: tx}
+}
+
+// GetByEventID returns the rules of an event, earliest cancellation window
+// first.
+func (r *refundPolicyRepository) GetByEventID(eventID uint) ([]entities.RefundPolicyRule, error) {
+	var rules []entities.RefundPolicyRule
+	err := r.db.Where("event_id = ?", eventID).Order("min_hours_before DESC").Find(&rules).Error
+	return rules, err
+}
+
+// ReplaceForEvent swaps the whole policy of an event for rules.
+func (r *refundPolicyRepository) ReplaceForEvent(eventID uint, rules []entities.RefundPolicyRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("event_id = ?", eventID).Delete(&entities.RefundPolicyRule{}).Error; err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefundRepository interface {
+	WithTx(tx *gorm.DB) RefundRepository
+	Create(refund *entities.Refund) error
+	GetByID(id uint) (*entities.Refund, error)
+	GetByTicketID(ticketID uint) (*entities.Refund, error)
+	GetPendingIDsByEventID(eventID uint) ([]uint, error)
+	UpdateResult(id uint, status string, gatewayRef string, processedAt *time.Time) error
+}
+
+type refundRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) RefundRepository {
+	return &refundRepository{db: db}
+}
+
+func (r *refundRepository) WithTx(tx *gorm.DB) RefundRepository {
+	return &refundRepository{db: tx}
+}
+
+func (r *refundRepository) Create(refund *entities.Refund) error {
+	return r.db.Create(refund).Error
+}
+
+func (r *refundRepository) GetByID(id uint) (*entities.Refund, error) {
+	var refund entities.Refund
+	err := r.db.First(&refund, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *refundRepository) GetByTicketID(ticketID uint) (*entities.Refund, error) {
+	var refund entities.Refund
+	err := r.db.Where("ticket_id = ?", ticketID).First(&refund).Error
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// GetPendingIDsByEventID returns the refunds of an event's tickets that have
+// not been sent to the gateway yet.
+func (r *refundRepository) GetPendingIDsByEventID(eventID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.Refund{}).
+		Joins("JOIN tickets ON tickets.id = refunds.ticket_id").
+		Where("tickets.event_id = ? AND refunds.status = ?", eventID, constants.RefundStatusPending).
+		Order("refunds.id ASC").
+		Pluck("refunds.id", &ids).Error
+	return ids, err
+}
+
+// UpdateResult records the gateway outcome of a refund.
+func (r *refundRepository) UpdateResult(id uint, status string, gatewayRef string, processedAt *time.Time) error {
+	return r.db.Model(&entities.Refund{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "gateway_ref": gatewayRef, "processed_at": processedAt}).Error
+}
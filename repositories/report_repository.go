@@ -19,6 +19,31 @@ type EventReport struct {
 	Revenue     float64 `json:"revenue"`
 }
 
+type TierSalesReport struct {
+	TierID      uint    `json:"tier_id"`
+	Name        string  `json:"name"`
+	Price       float64 `json:"price"`
+	Capacity    int     `json:"capacity"`
+	TicketsSold int     `json:"tickets_sold"`
+	Revenue     float64 `json:"revenue"`
+}
+
+type SeriesReport struct {
+	SeriesID uint                     `json:"series_id"`
+	Title    string                   `json:"title"`
+	Events   []SeriesOccurrenceReport `json:"events"`
+}
+
+type SeriesOccurrenceReport struct {
+	EventID     uint      `json:"event_id"`
+	Title       string    `json:"title"`
+	Date        time.Time `json:"date"`
+	Status      string    `json:"status"`
+	Capacity    int       `json:"capacity"`
+	TicketsSold int       `json:"tickets_sold"`
+	Revenue     float64   `json:"revenue"`
+}
+
 type SystemOverview struct {
 	TotalUsers       int64 `json:"total_users"`
 	TotalEvents      int64 `json:"total_events"`
@@ -87,6 +112,9 @@ type MonthlyStatsReport struct {
 type ReportRepository interface {
 	GetSummaryReport() (*SummaryReport, error)
 	GetEventReport(eventID uint) (*EventReport, error)
+	GetEventTierBreakdown(eventID uint) ([]TierSalesReport, error)
+	GetSeriesReport(seriesID uint) (*SeriesReport, error)
+	GetUserTotalSpend(userID uint) (float64, error)
 	GetSystemOverview() (*SystemOverview, error)
 	GetUserMetrics() (*UserMetrics, error)
 	GetEventMetrics() (*EventMetrics, error)
@@ -107,9 +135,11 @@ func NewReportRepository(db *gorm.DB) ReportRepository {
 
 func (r *reportRepository) GetSummaryReport() (*SummaryReport, error) {
 	var result SummaryReport
-	err := r.db.Model(&entities.Ticket{}).
-		Where("status = ?", "booked").
-		Select("SUM(quantity) as total_tickets, SUM(total_price) as total_revenue").
+	err := r.db.Table("tickets").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("tickets.deleted_at IS NULL").
+		Select("COALESCE(SUM(" + soldTicketQuantity + "), 0) as total_tickets, " +
+			"COALESCE(SUM(" + netTicketRevenue + "), 0) as total_revenue").
 		Scan(&result).Error
 
 	return &result, err
@@ -119,15 +149,70 @@ func (r *reportRepository) GetEventReport(eventID uint) (*EventReport, error) {
 	var result EventReport
 
 	err := r.db.Table("tickets").
-		Select("tickets.event_id, events.title, SUM(tickets.quantity) as tickets_sold, SUM(tickets.total_price) as revenue").
+		Select("tickets.event_id, events.title, "+
+			"COALESCE(SUM("+soldTicketQuantity+"), 0) as tickets_sold, "+
+			"COALESCE(SUM("+netTicketRevenue+"), 0) as revenue").
 		Joins("JOIN events ON tickets.event_id = events.id").
-		Where("tickets.event_id = ? AND tickets.status = ?", eventID, "booked").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("tickets.event_id = ? AND tickets.deleted_at IS NULL", eventID).
 		Group("tickets.event_id, events.title").
 		Scan(&result).Error
 
 	return &result, err
 }
 
+func (r *reportRepository) GetEventTierBreakdown(eventID uint) ([]TierSalesReport, error) {
+	var results []TierSalesReport
+
+	err := r.db.Table("ticket_tiers").
+		Select("ticket_tiers.id as tier_id, ticket_tiers.name, ticket_tiers.price, ticket_tiers.capacity, "+
+			"COALESCE(SUM("+soldTicketQuantity+"), 0) as tickets_sold, "+
+			"COALESCE(SUM("+netTicketRevenue+"), 0) as revenue").
+		Joins("LEFT JOIN tickets ON tickets.tier_id = ticket_tiers.id AND tickets.deleted_at IS NULL").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("ticket_tiers.event_id = ? AND ticket_tiers.deleted_at IS NULL", eventID).
+		Group("ticket_tiers.id, ticket_tiers.name, ticket_tiers.price, ticket_tiers.capacity").
+		Order("ticket_tiers.price DESC").
+		Scan(&results).Error
+
+	return results, err
+}
+
+// GetSeriesReport returns the sales of every occurrence of a series in date
+// order. Occurrences without sales are included with zero totals.
+func (r *reportRepository) GetSeriesReport(seriesID uint) (*SeriesReport, error) {
+	var series entities.EventSeries
+	if err := r.db.First(&series, seriesID).Error; err != nil {
+		return nil, err
+	}
+
+	result := SeriesReport{SeriesID: series.ID, Title: series.Title}
+	err := r.db.Table("events").
+		Select("events.id as event_id, events.title, events.date, events.status, events.capacity, "+
+			"COALESCE(SUM("+soldTicketQuantity+"), 0) as tickets_sold, "+
+			"COALESCE(SUM("+netTicketRevenue+"), 0) as revenue").
+		Joins("LEFT JOIN tickets ON tickets.event_id = events.id AND tickets.deleted_at IS NULL").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("events.series_id = ? AND events.deleted_at IS NULL", series.ID).
+		Group("events.id, events.title, events.date, events.status, events.capacity").
+		Order("events.date ASC").
+		Scan(&result.Events).Error
+
+	return &result, err
+}
+
+// GetUserTotalSpend returns what a user has paid for tickets, net of
+// refunds.
+func (r *reportRepository) GetUserTotalSpend(userID uint) (float64, error) {
+	var total float64
+	err := r.db.Table("tickets").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("tickets.user_id = ? AND tickets.deleted_at IS NULL", userID).
+		Select("COALESCE(SUM(" + netTicketRevenue + "), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
 func (r *reportRepository) GetSystemOverview() (*SystemOverview, error) {
 	var result SystemOverview
 
@@ -140,8 +225,8 @@ func (r *reportRepository) GetSystemOverview() (*SystemOverview, error) {
 	// Get total tickets
 	r.db.Model(&entities.Ticket{}).Count(&result.TotalTickets)
 
-	// Get active events
-	r.db.Model(&entities.Event{}).Where("status = ?", "upcoming").Count(&result.ActiveEvents)
+	// Get active events (not yet over)
+	r.db.Model(&entities.Event{}).Where("status IN ?", []string{"upcoming", "ongoing"}).Count(&result.ActiveEvents)
 
 	// Get completed events
 	r.db.Model(&entities.Event{}).Where("status = ?", "completed").Count(&result.CompletedEvents)
@@ -170,7 +255,7 @@ func (r *reportRepository) GetUserMetrics() (*UserMetrics, error) {
 	// Get active users (users who have booked tickets)
 	r.db.Model(&entities.User{}).
 		Joins("JOIN tickets ON users.id = tickets.user_id").
-		Where("tickets.status = ?", "booked").
+		Where("tickets.status IN (" + soldTicketStatuses + ")").
 		Group("users.id").
 		Count(&result.ActiveUsers)
 
@@ -189,8 +274,8 @@ func (r *reportRepository) GetEventMetrics() (*EventMetrics, error) {
 	// Get total events
 	r.db.Model(&entities.Event{}).Count(&result.TotalEvents)
 
-	// Get active events
-	r.db.Model(&entities.Event{}).Where("status = ?", "upcoming").Count(&result.ActiveEvents)
+	// Get active events (not yet over)
+	r.db.Model(&entities.Event{}).Where("status IN ?", []string{"upcoming", "ongoing"}).Count(&result.ActiveEvents)
 
 	// Get completed events
 	r.db.Model(&entities.Event{}).Where("status = ?", "completed").Count(&result.CompletedEvents)
@@ -213,8 +298,8 @@ func (r *reportRepository) GetTicketMetrics() (*TicketMetrics, error) {
 	// Get total tickets
 	r.db.Model(&entities.Ticket{}).Count(&result.TotalTickets)
 
-	// Get booked tickets
-	r.db.Model(&entities.Ticket{}).Where("status = ?", "booked").Count(&result.BookedTickets)
+	// Get sold tickets, checked in or not
+	r.db.Model(&entities.Ticket{}).Where("status IN (" + soldTicketStatuses + ")").Count(&result.BookedTickets)
 
 	// Get cancelled tickets
 	r.db.Model(&entities.Ticket{}).Where("status = ?", "cancelled").Count(&result.CancelledTickets)
@@ -228,32 +313,57 @@ func (r *reportRepository) GetTicketMetrics() (*TicketMetrics, error) {
 	return &result, nil
 }
 
+// soldTicketStatuses lists, for use in SQL, the statuses of tickets that
+// were paid and not cancelled, whether the holder was scanned at the gate,
+// is still to come or never showed up. Every report counts sales through it
+// so the totals agree with each other.
+const soldTicketStatuses = `'booked', 'used', 'no_show'`
+
+// soldTicketQuantity counts the seats of a ticket when it is sold.
+const soldTicketQuantity = `CASE WHEN tickets.status IN (` + soldTicketStatuses + `) THEN tickets.quantity ELSE 0 END`
+
+// netTicketRevenue is what is kept from a ticket: the full price of sold
+// tickets, and whatever was not refunded on paid tickets that were
+// cancelled later. Tickets cancelled before payment have no refund row and
+// count for nothing.
+const netTicketRevenue = `CASE
+	WHEN tickets.status IN (` + soldTicketStatuses + `) THEN tickets.total_price
+	WHEN refunds.id IS NOT NULL THEN tickets.total_price - CASE WHEN refunds.status = 'processed' THEN refunds.amount ELSE 0 END
+	ELSE 0 END`
+
 func (r *reportRepository) GetRevenueMetrics() (*RevenueMetrics, error) {
 	var result RevenueMetrics
 
-	// Get total revenue from booked tickets
-	r.db.Model(&entities.Ticket{}).
-		Where("status = ?", "booked").
-		Select("SUM(total_price)").
+	// Get total revenue, net of refunds
+	r.db.Table("tickets").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("tickets.deleted_at IS NULL").
+		Select("COALESCE(SUM(" + netTicketRevenue + "), 0)").
 		Scan(&result.TotalRevenue)
 
 	// Get monthly revenue
 	startOfMonth := time.Now().AddDate(0, 0, -time.Now().Day()+1)
-	r.db.Model(&entities.Ticket{}).
-		Where("status = ? AND created_at >= ?", "booked", startOfMonth).
-		Select("SUM(total_price)").
+	r.db.Table("tickets").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("tickets.deleted_at IS NULL AND tickets.created_at >= ?", startOfMonth).
+		Select("COALESCE(SUM(" + netTicketRevenue + "), 0)").
 		Scan(&result.MonthlyRevenue)
 
-	// Get average revenue per event
-	r.db.Table("tickets").
-		Select("AVG(event_revenue)").
-		Joins("JOIN (SELECT event_id, SUM(total_price) as event_revenue FROM tickets WHERE status = 'booked' GROUP BY event_id) as event_totals ON tickets.event_id = event_totals.event_id").
+	// Get average revenue per event that earned anything
+	r.db.Raw(`SELECT COALESCE(AVG(event_revenue), 0) FROM (
+		SELECT tickets.event_id, SUM(` + netTicketRevenue + `) as event_revenue
+		FROM tickets
+		LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL
+		WHERE tickets.deleted_at IS NULL
+		GROUP BY tickets.event_id
+		HAVING event_revenue > 0
+	) as event_totals`).
 		Scan(&result.AverageRevenue)
 
-	// Get refunded amount (cancelled tickets)
-	r.db.Model(&entities.Ticket{}).
-		Where("status = ?", "cancelled").
-		Select("SUM(total_price)").
+	// Get refunded amount (refunds actually paid out)
+	r.db.Model(&entities.Refund{}).
+		Where("status = ?", "processed").
+		Select("COALESCE(SUM(amount), 0)").
 		Scan(&result.RefundedAmount)
 
 	return &result, nil
@@ -263,10 +373,14 @@ func (r *reportRepository) GetTopEvents(limit int) ([]TopEventReport, error) {
 	var results []TopEventReport
 
 	err := r.db.Table("tickets").
-		Select("tickets.event_id, events.title, events.category, SUM(tickets.quantity) as tickets_sold, SUM(tickets.total_price) as revenue").
+		Select("tickets.event_id, events.title, events.category, " +
+			"COALESCE(SUM(" + soldTicketQuantity + "), 0) as tickets_sold, " +
+			"COALESCE(SUM(" + netTicketRevenue + "), 0) as revenue").
 		Joins("JOIN events ON tickets.event_id = events.id").
-		Where("tickets.status = ?", "booked").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("tickets.deleted_at IS NULL").
 		Group("tickets.event_id, events.title, events.category").
+		Having("tickets_sold > 0 OR revenue > 0").
 		Order("revenue DESC").
 		Limit(limit).
 		Scan(&results).Error
@@ -278,8 +392,12 @@ func (r *reportRepository) GetCategoryBreakdown() ([]CategoryBreakdownReport, er
 	var results []CategoryBreakdownReport
 
 	err := r.db.Table("events").
-		Select("events.category, COUNT(events.id) as event_count, COALESCE(SUM(tickets.quantity), 0) as tickets_sold, COALESCE(SUM(tickets.total_price), 0) as revenue").
-		Joins("LEFT JOIN tickets ON events.id = tickets.event_id AND tickets.status = 'booked'").
+		Select("events.category, COUNT(DISTINCT events.id) as event_count, " +
+			"COALESCE(SUM(" + soldTicketQuantity + "), 0) as tickets_sold, " +
+			"COALESCE(SUM(" + netTicketRevenue + "), 0) as revenue").
+		Joins("LEFT JOIN tickets ON events.id = tickets.event_id AND tickets.deleted_at IS NULL").
+		Joins("LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL").
+		Where("events.deleted_at IS NULL").
 		Group("events.category").
 		Order("revenue DESC").
 		Scan(&results).Error
@@ -309,10 +427,13 @@ func (r *reportRepository) GetMonthlyStats() ([]MonthlyStatsReport, error) {
 			GROUP BY DATE_FORMAT(created_at, '%Y-%m')
 		) event_stats ON months.month = event_stats.month
 		LEFT JOIN (
-			SELECT DATE_FORMAT(created_at, '%Y-%m') as month, SUM(quantity) as tickets, SUM(total_price) as revenue
+			SELECT DATE_FORMAT(tickets.created_at, '%Y-%m') as month,
+				SUM(` + soldTicketQuantity + `) as tickets,
+				SUM(` + netTicketRevenue + `) as revenue
 			FROM tickets
-			WHERE created_at >= DATE_SUB(CURDATE(), INTERVAL 6 MONTH) AND status = 'booked'
-			GROUP BY DATE_FORMAT(created_at, '%Y-%m')
+			LEFT JOIN refunds ON refunds.ticket_id = tickets.id AND refunds.deleted_at IS NULL
+			WHERE tickets.created_at >= DATE_SUB(CURDATE(), INTERVAL 6 MONTH) AND tickets.deleted_at IS NULL
+			GROUP BY DATE_FORMAT(tickets.created_at, '%Y-%m')
 		) ticket_stats ON months.month = ticket_stats.month
 		LEFT JOIN (
 			SELECT DATE_FORMAT(created_at, '%Y-%m') as month, COUNT(*) as new_users
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevokedTokenRepository interface {
+	WithTx(tx *gorm.DB) RevokedTokenRepository
+	Create(token *entities.RevokedToken) error
+	Exists(jti string) (bool, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type revokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository(db *gorm.DB) RevokedTokenRepository {
+	return &revokedTokenRepository{db: db}
+}
+
+func (r *revokedTokenRepository) WithTx(tx *gorm.DB) RevokedTokenRepository {
+	return &revokedTokenRepository{db: tx}
+}
+
+// Create adds a token to the revocation list. Revoking a token twice is
+// not an error.
+func (r *revokedTokenRepository) Create(token *entities.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (r *revokedTokenRepository) Exists(jti string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteExpired drops entries for tokens that have expired on their own,
+// which JWTAuth rejects without the list.
+func (r *revokedTokenRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("expires_at < ?", now).Delete(&entities.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
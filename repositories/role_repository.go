@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	WithTx(tx *gorm.DB) RoleRepository
+	GetAll() ([]entities.Role, error)
+	GetByID(id uint) (*entities.Role, error)
+	GetByName(name string) (*entities.Role, error)
+	Create(role *entities.Role) error
+	Update(role *entities.Role) error
+	ReplacePermissions(role *entities.Role, permissions []entities.Permission) error
+	Delete(role *entities.Role) error
+	CountUsers(name string) (int64, error)
+	GetPermissionNames(name string) ([]string, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) WithTx(tx *gorm.DB) RoleRepository {
+	return &roleRepository{db: tx}
+}
+
+func (r *roleRepository) GetAll() ([]entities.Role, error) {
+	var roles []entities.Role
+	err := r.db.Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name ASC")
+	}).Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepository) GetByID(id uint) (*entities.Role, error) {
+	var role entities.Role
+	err := r.db.Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name ASC")
+	}).First(&role, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) GetByName(name string) (*entities.Role, error) {
+	var role entities.Role
+	err := r.db.Where("name = ?", name).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) Create(role *entities.Role) error {
+	return r.db.Create(role).Error
+}
+
+// Update saves the role's own columns; permissions are changed through
+// ReplacePermissions.
+func (r *roleRepository) Update(role *entities.Role) error {
+	return r.db.Omit("Permissions").Save(role).Error
+}
+
+func (r *roleRepository) ReplacePermissions(role *entities.Role, permissions []entities.Permission) error {
+	return r.db.Model(role).Association("Permissions").Replace(permissions)
+}
+
+// Delete hard deletes a role and its permission grants, so its name can be
+// used again.
+func (r *roleRepository) Delete(role *entities.Role) error {
+	return r.db.Select("Permissions").Unscoped().Delete(role).Error
+}
+
+// CountUsers counts the users holding the role.
+func (r *roleRepository) CountUsers(name string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.User{}).Where("role = ?", name).Count(&count).Error
+	return count, err
+}
+
+// GetPermissionNames returns the permissions granted to the role with the
+// given name, or none when no such role exists.
+func (r *roleRepository) GetPermissionNames(name string) ([]string, error) {
+	var names []string
+	err := r.db.Table("permissions").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ? AND roles.deleted_at IS NULL AND permissions.deleted_at IS NULL", name).
+		Pluck("permissions.name", &names).Error
+	return names, err
+}
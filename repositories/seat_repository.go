@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrSeatsUnavailable is returned when a conditional seat update finds that
+// one of the seats was already taken.
+var ErrSeatsUnavailable = errors.New("seats unavailable")
+
+type SeatRepository interface {
+	WithTx(tx *gorm.DB) SeatRepository
+	GetSectionsByEventID(eventID uint) ([]entities.SeatSection, error)
+	GetSectionsByVenueID(venueID uint) ([]entities.SeatSection, error)
+	ReplaceEventMap(eventID uint, sections []entities.SeatSection) error
+	ReplaceVenueMap(venueID uint, sections []entities.SeatSection) error
+	CountByEventID(eventID uint) (int64, error)
+	GetByIDs(eventID uint, ids []uint) ([]entities.Seat, error)
+	GetByHoldID(holdID uint) ([]entities.Seat, error)
+	GetAvailableIDs(eventID uint, limit int) ([]uint, error)
+	Hold(eventID uint, ids []uint, holdID uint) error
+	Book(eventID uint, ids []uint, ticketID uint) error
+	BookHeld(holdID uint, ticketID uint) error
+	ReleaseByHoldID(holdID uint) error
+	ReleaseByTicketID(ticketID uint) error
+}
+
+type seatRepository struct {
+	db *gorm.DB
+}
+
+func NewSeatRepository(db *gorm.DB) SeatRepository {
+	return &seatRepository{db: db}
+}
+
+func (r *seatRepository) WithTx(tx *gorm.DB) SeatRepository {
+	return &seatRepository{db: tx}
+}
+
+func (r *seatRepository) GetSectionsByEventID(eventID uint) ([]entities.SeatSection, error) {
+	return r.getSections("event_id = ?", eventID)
+}
+
+func (r *seatRepository) GetSectionsByVenueID(venueID uint) ([]entities.SeatSection, error) {
+	return r.getSections("venue_id = ?", venueID)
+}
+
+func (r *seatRepository) getSections(query string, id uint) ([]entities.SeatSection, error) {
+	var sections []entities.SeatSection
+	err := r.db.Preload("Seats", func(db *gorm.DB) *gorm.DB {
+		return db.Order("`row` ASC, number ASC")
+	}).Where(query, id).Order("id ASC").Find(&sections).Error
+	return sections, err
+}
+
+// ReplaceEventMap deletes the seat map of an event and saves sections as its
+// new one.
+func (r *seatRepository) ReplaceEventMap(eventID uint, sections []entities.SeatSection) error {
+	for i := range sections {
+		sections[i].EventID = &eventID
+		for j := range sections[i].Seats {
+			sections[i].Seats[j].EventID = &eventID
+		}
+	}
+	return r.replaceMap("event_id = ?", eventID, sections)
+}
+
+// ReplaceVenueMap deletes the seat map template of a venue and saves
+// sections as its new one.
+func (r *seatRepository) ReplaceVenueMap(venueID uint, sections []entities.SeatSection) error {
+	for i := range sections {
+		sections[i].VenueID = &venueID
+	}
+	return r.replaceMap("venue_id = ?", venueID, sections)
+}
+
+func (r *seatRepository) replaceMap(query string, id uint, sections []entities.SeatSection) error {
+	var sectionIDs []uint
+	if err := r.db.Model(&entities.SeatSection{}).Where(query, id).Pluck("id", &sectionIDs).Error; err != nil {
+		return err
+	}
+
+	// Hard delete so the unique seat positions can be reused
+	if len(sectionIDs) > 0 {
+		if err := r.db.Unscoped().Where("section_id IN ?", sectionIDs).Delete(&entities.Seat{}).Error; err != nil {
+			return err
+		}
+		if err := r.db.Unscoped().Where("id IN ?", sectionIDs).Delete(&entities.SeatSection{}).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(sections) == 0 {
+		return nil
+	}
+	// Large maps are inserted in batches to stay under placeholder limits
+	return r.db.Session(&gorm.Session{CreateBatchSize: 500}).Create(&sections).Error
+}
+
+func (r *seatRepository) CountByEventID(eventID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.Seat{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
+func (r *seatRepository) GetByIDs(eventID uint, ids []uint) ([]entities.Seat, error) {
+	var seats []entities.Seat
+	err := r.db.Preload("Section").Where("event_id = ? AND id IN ?", eventID, ids).Find(&seats).Error
+	return seats, err
+}
+
+func (r *seatRepository) GetByHoldID(holdID uint) ([]entities.Seat, error) {
+	var seats []entities.Seat
+	err := r.db.Preload("Section").Where("hold_id = ?", holdID).Find(&seats).Error
+	return seats, err
+}
+
+// GetAvailableIDs returns up to limit free seats of an event in seat map
+// order.
+func (r *seatRepository) GetAvailableIDs(eventID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.Seat{}).
+		Where("event_id = ? AND status = ?", eventID, constants.SeatStatusAvailable).
+		Order("section_id ASC, `row` ASC, number ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// Hold atomically marks the seats as held by a hold, failing with
+// ErrSeatsUnavailable unless every one of them is still available.
+func (r *seatRepository) Hold(eventID uint, ids []uint, holdID uint) error {
+	return r.claim(eventID, ids, map[string]interface{}{
+		"status":  constants.SeatStatusHeld,
+		"hold_id": holdID,
+	})
+}
+
+// Book atomically assigns the seats to a ticket, failing with
+// ErrSeatsUnavailable unless every one of them is still available.
+func (r *seatRepository) Book(eventID uint, ids []uint, ticketID uint) error {
+	return r.claim(eventID, ids, map[string]interface{}{
+		"status":    constants.SeatStatusBooked,
+		"ticket_id": ticketID,
+	})
+}
+
+func (r *seatRepository) claim(eventID uint, ids []uint, columns map[string]interface{}) error {
+	result := r.db.Model(&entities.Seat{}).
+		Where("event_id = ? AND id IN ? AND status = ?", eventID, ids, constants.SeatStatusAvailable).
+		UpdateColumns(columns)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(ids)) {
+		return ErrSeatsUnavailable
+	}
+	return nil
+}
+
+// BookHeld moves the seats of a confirmed hold to its ticket.
+func (r *seatRepository) BookHeld(holdID uint, ticketID uint) error {
+	return r.db.Model(&entities.Seat{}).
+		Where("hold_id = ? AND status = ?", holdID, constants.SeatStatusHeld).
+		UpdateColumns(map[string]interface{}{
+			"status":    constants.SeatStatusBooked,
+			"hold_id":   nil,
+			"ticket_id": ticketID,
+		}).Error
+}
+
+func (r *seatRepository) ReleaseByHoldID(holdID uint) error {
+	return r.release("hold_id = ?", holdID)
+}
+
+func (r *seatRepository) ReleaseByTicketID(ticketID uint) error {
+	return r.release("ticket_id = ?", ticketID)
+}
+
+func (r *seatRepository) release(query string, id uint) error {
+	return r.db.Model(&entities.Seat{}).
+		Where(query, id).
+		UpdateColumns(map[string]interface{}{
+			"status":    constants.SeatStatusAvailable,
+			"hold_id":   nil,
+			"ticket_id": nil,
+		}).Error
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSessionFull is returned when a conditional registered update would push
+// a session past its capacity.
+var ErrSessionFull = errors.New("session is full")
+
+type SessionRepository interface {
+	WithTx(tx *gorm.DB) SessionRepository
+	GetByEventID(eventID uint) ([]entities.Session, error)
+	GetByID(id uint) (*entities.Session, error)
+	GetByIDForUpdate(id uint) (*entities.Session, error)
+	Create(session *entities.Session) error
+	Update(session *entities.Session, speakers []entities.Speaker) error
+	Delete(session *entities.Session) error
+	FindRoomConflict(eventID uint, room string, start, end time.Time, excludeID uint) (*entities.Session, error)
+	IncrementRegistered(id uint) error
+	DecrementRegistered(id uint) error
+	IsRegistered(sessionID uint, userID uint) (bool, error)
+	GetRegisteredSessionIDs(eventID uint, userID uint) ([]uint, error)
+	FindRegistrationConflict(eventID uint, userID uint, start, end time.Time) (*entities.Session, error)
+	CreateRegistration(registration *entities.SessionRegistration) error
+	DeleteRegistration(sessionID uint, userID uint) (bool, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) WithTx(tx *gorm.DB) SessionRepository {
+	return &sessionRepository{db: tx}
+}
+
+func (r *sessionRepository) GetByEventID(eventID uint) ([]entities.Session, error) {
+	var sessions []entities.Session
+	err := r.db.Preload("Speakers").
+		Where("event_id = ?", eventID).
+		Order("start_time ASC, id ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *sessionRepository) GetByID(id uint) (*entities.Session, error) {
+	var session entities.Session
+	err := r.db.Preload("Speakers").First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetByIDForUpdate loads a session and locks its row until the surrounding
+// transaction ends, so registrations for it are serialized.
+func (r *sessionRepository) GetByIDForUpdate(id uint) (*entities.Session, error) {
+	var session entities.Session
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&session, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Create saves the session and links it to its speakers, which must
+// already exist.
+func (r *sessionRepository) Create(session *entities.Session) error {
+	return r.db.Omit("Speakers.*").Create(session).Error
+}
+
+// Update saves the session fields. When speakers is not nil it replaces
+// the speakers of the session. registered is left to the conditional
+// updates.
+func (r *sessionRepository) Update(session *entities.Session, speakers []entities.Speaker) error {
+	if err := r.db.Omit(clause.Associations, "Registered").Save(session).Error; err != nil {
+		return err
+	}
+	if speakers == nil {
+		return nil
+	}
+	return r.db.Model(session).Association("Speakers").Replace(speakers)
+}
+
+func (r *sessionRepository) Delete(session *entities.Session) error {
+	return r.db.Select("Speakers").Delete(session).Error
+}
+
+// FindRoomConflict returns a session of the event held in room at some
+// point between start and end, or nil when the room is free.
+func (r *sessionRepository) FindRoomConflict(eventID uint, room string, start, end time.Time, excludeID uint) (*entities.Session, error) {
+	var sessions []entities.Session
+	err := r.db.
+		Where("event_id = ? AND room = ? AND id <> ?", eventID, room, excludeID).
+		Where("start_time < ? AND end_time > ?", end, start).
+		Limit(1).
+		Find(&sessions).Error
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	return &sessions[0], nil
+}
+
+// IncrementRegistered atomically takes a place in the session, failing
+// with ErrSessionFull when none is left.
+func (r *sessionRepository) IncrementRegistered(id uint) error {
+	result := r.db.Model(&entities.Session{}).
+		Where("id = ? AND registered < capacity", id).
+		UpdateColumn("registered", gorm.Expr("registered + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSessionFull
+	}
+	return nil
+}
+
+// DecrementRegistered atomically gives a place back, never letting
+// registered drop below zero.
+func (r *sessionRepository) DecrementRegistered(id uint) error {
+	return r.db.Model(&entities.Session{}).
+		Where("id = ? AND registered > 0", id).
+		UpdateColumn("registered", gorm.Expr("registered - 1")).Error
+}
+
+func (r *sessionRepository) IsRegistered(sessionID uint, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.SessionRegistration{}).
+		Where("session_id = ? AND user_id = ?", sessionID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *sessionRepository) GetRegisteredSessionIDs(eventID uint, userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.SessionRegistration{}).
+		Joins("JOIN sessions ON sessions.id = session_registrations.session_id AND sessions.deleted_at IS NULL").
+		Where("sessions.event_id = ? AND session_registrations.user_id = ?", eventID, userID).
+		Pluck("session_registrations.session_id", &ids).Error
+	return ids, err
+}
+
+// FindRegistrationConflict returns a session of the event the user is
+// already registered for that overlaps start to end, or nil.
+func (r *sessionRepository) FindRegistrationConflict(eventID uint, userID uint, start, end time.Time) (*entities.Session, error) {
+	var sessions []entities.Session
+	err := r.db.
+		Joins("JOIN session_registrations ON session_registrations.session_id = sessions.id AND session_registrations.deleted_at IS NULL").
+		Where("sessions.event_id = ? AND session_registrations.user_id = ?", eventID, userID).
+		Where("sessions.start_time < ? AND sessions.end_time > ?", end, start).
+		Limit(1).
+		Find(&sessions).Error
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	return &sessions[0], nil
+}
+
+func (r *sessionRepository) CreateRegistration(registration *entities.SessionRegistration) error {
+	return r.db.Create(registration).Error
+}
+
+// DeleteRegistration hard deletes a registration so the user may register
+// again, and reports whether there was one.
+func (r *sessionRepository) DeleteRegistration(sessionID uint, userID uint) (bool, error) {
+	result := r.db.Unscoped().
+		Where("session_id = ? AND user_id = ?", sessionID, userID).
+		Delete(&entities.SessionRegistration{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettingRepository interface {
+	Get(key string) (*entities.Setting, error)
+	Set(key string, value string) error
+}
+
+type settingRepository struct {
+	db *gorm.DB
+}
+
+func NewSettingRepository(db *gorm.DB) SettingRepository {
+	return &settingRepository{db: db}
+}
+
+// Get returns the stored setting, or nil when it was never set.
+func (r *settingRepository) Get(key string) (*entities.Setting, error) {
+	var settings []entities.Setting
+	err := r.db.Where("`key` = ?", key).Limit(1).Find(&settings).Error
+	if err != nil || len(settings) == 0 {
+		return nil, err
+	}
+	return &settings[0], nil
+}
+
+// Set stores a setting, overwriting the previous value.
+func (r *settingRepository) Set(key string, value string) error {
+	setting := entities.Setting{Key: key, Value: value}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&setting).Error
+}
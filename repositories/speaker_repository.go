@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SpeakerRepository interface {
+	WithTx(tx *gorm.DB) SpeakerRepository
+	GetByEventID(eventID uint) ([]entities.Speaker, error)
+	GetByID(id uint) (*entities.Speaker, error)
+	GetByIDs(eventID uint, ids []uint) ([]entities.Speaker, error)
+	Create(speaker *entities.Speaker) error
+	Update(speaker *entities.Speaker) error
+	Delete(speaker *entities.Speaker) error
+}
+
+type speakerRepository struct {
+	db *gorm.DB
+}
+
+func NewSpeakerRepository(db *gorm.DB) SpeakerRepository {
+	return &speakerRepository{db: db}
+}
+
+func (r *speakerRepository) WithTx(tx *gorm.DB) SpeakerRepository {
+	return &speakerRepository{db: tx}
+}
+
+func (r *speakerRepository) GetByEventID(eventID uint) ([]entities.Speaker, error) {
+	var speakers []entities.Speaker
+	err := r.db.Where("event_id = ?", eventID).Order("name ASC").Find(&speakers).Error
+	return speakers, err
+}
+
+func (r *speakerRepository) GetByID(id uint) (*entities.Speaker, error) {
+	var speaker entities.Speaker
+	err := r.db.First(&speaker, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &speaker, nil
+}
+
+func (r *speakerRepository) GetByIDs(eventID uint, ids []uint) ([]entities.Speaker, error) {
+	var speakers []entities.Speaker
+	err := r.db.Where("event_id = ? AND id IN ?", eventID, ids).Find(&speakers).Error
+	return speakers, err
+}
+
+func (r *speakerRepository) Create(speaker *entities.Speaker) error {
+	return r.db.Create(speaker).Error
+}
+
+func (r *speakerRepository) Update(speaker *entities.Speaker) error {
+	return r.db.Omit(clause.Associations).Save(speaker).Error
+}
+
+// Delete removes the speaker and unlinks it from its sessions.
+func (r *speakerRepository) Delete(speaker *entities.Speaker) error {
+	return r.db.Select("Sessions").Delete(speaker).Error
+}
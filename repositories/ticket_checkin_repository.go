@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+)
+
+type TicketCheckInRepository interface {
+	WithTx(tx *gorm.DB) TicketCheckInRepository
+	Create(checkIn *entities.TicketCheckIn) error
+	GetByTicketID(ticketID uint) ([]entities.TicketCheckIn, error)
+}
+
+type ticketCheckInRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketCheckInRepository(db *gorm.DB) TicketCheckInRepository {
+	return &ticketCheckInRepository{db: db}
+}
+
+func (r *ticketCheckInRepository) WithTx(tx *gorm.DB) TicketCheckInRepository {
+	return &ticketCheckInRepository{db: tx}
+}
+
+func (r *ticketCheckInRepository) Create(checkIn *entities.TicketCheckIn) error {
+	return r.db.Create(checkIn).Error
+}
+
+func (r *ticketCheckInRepository) GetByTicketID(ticketID uint) ([]entities.TicketCheckIn, error) {
+	var checkIns []entities.TicketCheckIn
+	err := r.db.Where("ticket_id = ?", ticketID).Order("scanned_at ASC").Find(&checkIns).Error
+	return checkIns, err
+}
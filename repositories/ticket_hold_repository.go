@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TicketHoldRepository interface {
+	WithTx(tx *gorm.DB) TicketHoldRepository
+	Create(hold *entities.TicketHold) error
+	GetByTokenForUpdate(token string) (*entities.TicketHold, error)
+	GetByIDForUpdate(id uint) (*entities.TicketHold, error)
+	GetExpiredActiveIDs(now time.Time, limit int) ([]uint, error)
+	Update(hold *entities.TicketHold) error
+}
+
+type ticketHoldRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketHoldRepository(db *gorm.DB) TicketHoldRepository {
+	return &ticketHoldRepository{db: db}
+}
+
+func (r *ticketHoldRepository) WithTx(tx *gorm.DB) TicketHoldRepository {
+	return &ticketHoldRepository{db: tx}
+}
+
+func (r *ticketHoldRepository) Create(hold *entities.TicketHold) error {
+	return r.db.Create(hold).Error
+}
+
+// GetByTokenForUpdate loads a hold by its token and locks the row until the
+// surrounding transaction ends.
+func (r *ticketHoldRepository) GetByTokenForUpdate(token string) (*entities.TicketHold, error) {
+	var hold entities.TicketHold
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token = ?", token).
+		First(&hold).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// GetByIDForUpdate loads a hold by ID and locks the row until the surrounding
+// transaction ends.
+func (r *ticketHoldRepository) GetByIDForUpdate(id uint) (*entities.TicketHold, error) {
+	var hold entities.TicketHold
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// GetExpiredActiveIDs returns the IDs of active holds whose TTL has passed.
+func (r *ticketHoldRepository) GetExpiredActiveIDs(now time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.TicketHold{}).
+		Where("status = ? AND expires_at <= ?", constants.HoldStatusActive, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *ticketHoldRepository) Update(hold *entities.TicketHold) error {
+	return r.db.Save(hold).Error
+}
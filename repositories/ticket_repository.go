@@ -1,17 +1,28 @@
 package repositories
 
 import (
+	"case_study_api/constants"
 	"case_study_api/entities"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type TicketRepository interface {
+	WithTx(tx *gorm.DB) TicketRepository
 	GetByUserID(userID uint) ([]entities.Ticket, error)
 	GetByUserIDPaginated(userID uint, offset, limit int) ([]entities.Ticket, int64, error)
+	GetByUserIDCursor(userID uint, keyset *Keyset, limit int) ([]entities.Ticket, bool, error)
 	GetByID(id uint) (*entities.Ticket, error)
+	GetByIDForUpdate(id uint) (*entities.Ticket, error)
+	GetByBookingCodeForUpdate(code string) (*entities.Ticket, error)
+	ExistsByBookingCode(code string) (bool, error)
 	Create(ticket *entities.Ticket) error
 	Update(ticket *entities.Ticket) error
+	MarkNoShows(eventID uint) (int64, error)
+	HasValidTicket(userID uint, eventID uint) (bool, error)
+	CountActiveByEventID(eventID uint) (int64, error)
+	GetActiveIDsByEventID(eventID uint, afterID uint, limit int) ([]uint, error)
 }
 
 type ticketRepository struct {
@@ -22,9 +33,13 @@ func NewTicketRepository(db *gorm.DB) TicketRepository {
 	return &ticketRepository{db: db}
 }
 
+func (r *ticketRepository) WithTx(tx *gorm.DB) TicketRepository {
+	return &ticketRepository{db: tx}
+}
+
 func (r *ticketRepository) GetByUserID(userID uint) ([]entities.Ticket, error) {
 	var tickets []entities.Ticket
-	err := r.db.Preload("Event").Where("user_id = ?", userID).Find(&tickets).Error
+	err := r.db.Preload("Event").Preload("Tier").Preload("Refund").Preload("PromoCode").Preload("Seats.Section").Where("user_id = ?", userID).Find(&tickets).Error
 	return tickets, err
 }
 
@@ -38,19 +53,63 @@ func (r *ticketRepository) GetByUserIDPaginated(userID uint, offset, limit int)
 	}
 
 	// Get paginated results
-	err := r.db.Preload("Event").Where("user_id = ?", userID).Offset(offset).Limit(limit).Find(&tickets).Error
+	err := r.db.Preload("Event").Preload("Tier").Preload("Refund").Preload("PromoCode").Preload("Seats.Section").Where("user_id = ?", userID).Offset(offset).Limit(limit).Find(&tickets).Error
 	return tickets, total, err
 }
 
+// GetByUserIDCursor returns the page of a user's tickets following keyset in
+// ID order, along with whether more tickets exist in that direction.
+func (r *ticketRepository) GetByUserIDCursor(userID uint, keyset *Keyset, limit int) ([]entities.Ticket, bool, error) {
+	var tickets []entities.Ticket
+
+	query := r.db.Preload("Event").Preload("Tier").Preload("Refund").Preload("PromoCode").Preload("Seats.Section").Where("user_id = ?", userID)
+	if err := keysetPage(query, "id", false, keyset, limit).Find(&tickets).Error; err != nil {
+		return nil, false, err
+	}
+
+	tickets, hasMore := trimKeysetPage(tickets, limit, keyset != nil && keyset.Backward)
+	return tickets, hasMore, nil
+}
+
 func (r *ticketRepository) GetByID(id uint) (*entities.Ticket, error) {
 	var ticket entities.Ticket
-	err := r.db.Preload("Event").First(&ticket, id).Error
+	err := r.db.Preload("Event").Preload("Tier").Preload("Refund").Preload("PromoCode").Preload("Seats.Section").Preload("User").First(&ticket, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// GetByIDForUpdate loads a ticket and locks its row until the surrounding
+// transaction ends. It must be called on a repository bound with WithTx.
+func (r *ticketRepository) GetByIDForUpdate(id uint) (*entities.Ticket, error) {
+	var ticket entities.Ticket
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ticket, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// GetByBookingCodeForUpdate loads a ticket by booking code and locks its row
+// until the surrounding transaction ends.
+func (r *ticketRepository) GetByBookingCodeForUpdate(code string) (*entities.Ticket, error) {
+	var ticket entities.Ticket
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("booking_code = ?", code).
+		First(&ticket).Error
 	if err != nil {
 		return nil, err
 	}
 	return &ticket, nil
 }
 
+func (r *ticketRepository) ExistsByBookingCode(code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.Ticket{}).Unscoped().Where("booking_code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
 func (r *ticketRepository) Create(ticket *entities.Ticket) error {
 	return r.db.Create(ticket).Error
 }
@@ -58,3 +117,43 @@ func (r *ticketRepository) Create(ticket *entities.Ticket) error {
 func (r *ticketRepository) Update(ticket *entities.Ticket) error {
 	return r.db.Save(ticket).Error
 }
+
+// MarkNoShows flags the booked tickets of an event that were never scanned
+// at the gate. No-shows were paid for and still count as sold in reports.
+func (r *ticketRepository) MarkNoShows(eventID uint) (int64, error) {
+	result := r.db.Model(&entities.Ticket{}).
+		Where("event_id = ? AND status = ? AND checked_in_count = 0", eventID, constants.TicketStatusBooked).
+		UpdateColumn("status", constants.TicketStatusNoShow)
+	return result.RowsAffected, result.Error
+}
+
+// HasValidTicket reports whether the user holds a paid ticket for the
+// event, whether or not it was checked in yet.
+func (r *ticketRepository) HasValidTicket(userID uint, eventID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.Ticket{}).
+		Where("user_id = ? AND event_id = ? AND status IN ?", userID, eventID, []string{constants.TicketStatusBooked, constants.TicketStatusUsed}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountActiveByEventID counts the pending and booked tickets of an event.
+func (r *ticketRepository) CountActiveByEventID(eventID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.Ticket{}).
+		Where("event_id = ? AND status IN ?", eventID, []string{constants.TicketStatusPending, constants.TicketStatusBooked}).
+		Count(&count).Error
+	return count, err
+}
+
+// GetActiveIDsByEventID pages through the pending and booked tickets of an
+// event in ID order, starting after afterID.
+func (r *ticketRepository) GetActiveIDsByEventID(eventID uint, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&entities.Ticket{}).
+		Where("event_id = ? AND id > ? AND status IN ?", eventID, afterID, []string{constants.TicketStatusPending, constants.TicketStatusBooked}).
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInsufficientTierCapacity is returned when a conditional sold update
+// would push a tier past its capacity.
+var ErrInsufficientTierCapacity = errors.New("insufficient tier capacity")
+
+type TicketTierRepository interface {
+	WithTx(tx *gorm.DB) TicketTierRepository
+	GetByEventID(eventID uint) ([]entities.TicketTier, error)
+	GetByID(id uint) (*entities.TicketTier, error)
+	GetByIDForUpdate(id uint) (*entities.TicketTier, error)
+	CountByEventID(eventID uint) (int64, error)
+	SumCapacityByEventID(eventID uint, excludeID uint) (int, error)
+	Create(tier *entities.TicketTier) error
+	Update(tier *entities.TicketTier) error
+	Delete(tier *entities.TicketTier) error
+	CountTickets(id uint) (int64, error)
+	IncrementSold(id uint, quantity int) error
+	DecrementSold(id uint, quantity int) error
+}
+
+type ticketTierRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketTierRepository(db *gorm.DB) TicketTierRepository {
+	return &ticketTierRepository{db: db}
+}
+
+func (r *ticketTierRepository) WithTx(tx *gorm.DB) TicketTierRepository {
+	return &ticketTierRepository{db: tx}
+}
+
+func (r *ticketTierRepository) GetByEventID(eventID uint) ([]entities.TicketTier, error) {
+	var tiers []entities.TicketTier
+	err := r.db.Where("event_id = ?", eventID).Order("price ASC").Find(&tiers).Error
+	return tiers, err
+}
+
+func (r *ticketTierRepository) GetByID(id uint) (*entities.TicketTier, error) {
+	var tier entities.TicketTier
+	err := r.db.First(&tier, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+// GetByIDForUpdate loads a tier and locks its row until the surrounding
+// transaction ends.
+func (r *ticketTierRepository) GetByIDForUpdate(id uint) (*entities.TicketTier, error) {
+	var tier entities.TicketTier
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tier, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *ticketTierRepository) CountByEventID(eventID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.TicketTier{}).Where("event_id = ?", eventID).Count(&count).Error
+	return count, err
+}
+
+// SumCapacityByEventID adds up the capacity of every tier of an event,
+// skipping excludeID so an update can be checked against its siblings.
+func (r *ticketTierRepository) SumCapacityByEventID(eventID uint, excludeID uint) (int, error) {
+	var total int
+	err := r.db.Model(&entities.TicketTier{}).
+		Where("event_id = ? AND id <> ?", eventID, excludeID).
+		Select("COALESCE(SUM(capacity), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *ticketTierRepository) Create(tier *entities.TicketTier) error {
+	return r.db.Create(tier).Error
+}
+
+// Update saves the editable tier fields; sold is only changed through
+// IncrementSold/DecrementSold.
+func (r *ticketTierRepository) Update(tier *entities.TicketTier) error {
+	return r.db.Omit("Sold").Save(tier).Error
+}
+
+// Delete hard deletes a tier so its name can be used again for the event.
+// Tiers that tickets point to cannot be deleted.
+func (r *ticketTierRepository) Delete(tier *entities.TicketTier) error {
+	return r.db.Unscoped().Delete(tier).Error
+}
+
+// CountTickets counts every ticket ever issued for a tier, cancelled and
+// expired ones included.
+func (r *ticketTierRepository) CountTickets(id uint) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&entities.Ticket{}).Where("tier_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// IncrementSold atomically adds quantity to sold only when the result stays
+// within the tier capacity.
+func (r *ticketTierRepository) IncrementSold(id uint, quantity int) error {
+	result := r.db.Model(&entities.TicketTier{}).
+		Where("id = ? AND sold + ? <= capacity", id, quantity).
+		UpdateColumn("sold", gorm.Expr("sold + ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientTierCapacity
+	}
+	return nil
+}
+
+func (r *ticketTierRepository) DecrementSold(id uint, quantity int) error {
+	return r.db.Model(&entities.TicketTier{}).
+		Where("id = ? AND sold >= ?", id, quantity).
+		UpdateColumn("sold", gorm.Expr("sold - ?", quantity)).Error
+}
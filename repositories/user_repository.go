@@ -1,14 +1,36 @@
 package repositories
 
 import (
+	"case_study_api/constants"
 	"case_study_api/entities"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// UserFilter narrows down a user listing. Zero values mean no filter.
+type UserFilter struct {
+	Role           string
+	Status         string
+	RegisteredFrom *time.Time
+	RegisteredTo   *time.Time
+	Search         string
+}
+
 type UserRepository interface {
+	WithTx(tx *gorm.DB) UserRepository
 	FindByEmail(email string) (*entities.User, error)
+	FindByID(id uint) (*entities.User, error)
+	GetAllPaginated(filter UserFilter, offset, limit int) ([]entities.User, int64, error)
+	FindByCalendarTokenHash(hash string) (*entities.User, error)
 	Create(user *entities.User) error
+	Update(user *entities.User) error
+	UpdateCalendarTokenHash(id uint, hash *string) error
+	MarkEmailVerified(id uint, verifiedAt time.Time) error
+	UpdatePassword(id uint, hashedPassword string) error
+	UpdateRole(id uint, role string) error
+	UpdateSuspension(id uint, suspendedAt *time.Time, reason string) error
 }
 
 type userRepository struct {
@@ -19,6 +41,10 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
+func (r *userRepository) WithTx(tx *gorm.DB) UserRepository {
+	return &userRepository{db: tx}
+}
+
 func (r *userRepository) FindByEmail(email string) (*entities.User, error) {
 	var user entities.User
 	err := r.db.Where("email = ?", email).First(&user).Error
@@ -28,6 +54,104 @@ func (r *userRepository) FindByEmail(email string) (*entities.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) FindByID(id uint) (*entities.User, error) {
+	var user entities.User
+	err := r.db.First(&user, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetAllPaginated lists users matching filter, newest first.
+func (r *userRepository) GetAllPaginated(filter UserFilter, offset, limit int) ([]entities.User, int64, error) {
+	var users []entities.User
+	var total int64
+
+	if err := r.filtered(filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.filtered(filter).
+		Order("created_at DESC").
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&users).Error
+	return users, total, err
+}
+
+func (r *userRepository) filtered(filter UserFilter) *gorm.DB {
+	query := r.db.Model(&entities.User{})
+
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	switch filter.Status {
+	case constants.UserStatusActive:
+		query = query.Where("suspended_at IS NULL")
+	case constants.UserStatusSuspended:
+		query = query.Where("suspended_at IS NOT NULL")
+	}
+	if filter.RegisteredFrom != nil {
+		query = query.Where("created_at >= ?", *filter.RegisteredFrom)
+	}
+	if filter.RegisteredTo != nil {
+		query = query.Where("created_at <= ?", *filter.RegisteredTo)
+	}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where("name LIKE ? OR email LIKE ?", pattern, pattern)
+	}
+
+	return query
+}
+
+func (r *userRepository) FindByCalendarTokenHash(hash string) (*entities.User, error) {
+	var user entities.User
+	err := r.db.Where("calendar_token_hash = ?", hash).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) Create(user *entities.User) error {
 	return r.db.Create(user).Error
 }
+
+// Update saves the user's own columns, leaving their events and tickets
+// untouched.
+func (r *userRepository) Update(user *entities.User) error {
+	return r.db.Omit(clause.Associations).Save(user).Error
+}
+
+// UpdateCalendarTokenHash replaces the calendar feed token of a user. A nil
+// hash turns the feed off.
+func (r *userRepository) UpdateCalendarTokenHash(id uint, hash *string) error {
+	return r.db.Model(&entities.User{}).Where("id = ?", id).Update("calendar_token_hash", hash).Error
+}
+
+// MarkEmailVerified records when a user confirmed their email address. An
+// earlier verification is kept.
+func (r *userRepository) MarkEmailVerified(id uint, verifiedAt time.Time) error {
+	return r.db.Model(&entities.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", verifiedAt).Error
+}
+
+func (r *userRepository) UpdatePassword(id uint, hashedPassword string) error {
+	return r.db.Model(&entities.User{}).Where("id = ?", id).Update("password", hashedPassword).Error
+}
+
+func (r *userRepository) UpdateRole(id uint, role string) error {
+	return r.db.Model(&entities.User{}).Where("id = ?", id).Update("role", role).Error
+}
+
+// UpdateSuspension suspends a user, or lifts the suspension when
+// suspendedAt is nil.
+func (r *userRepository) UpdateSuspension(id uint, suspendedAt *time.Time, reason string) error {
+	return r.db.Model(&entities.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"suspended_at":      suspendedAt,
+		"suspension_reason": reason,
+	}).Error
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"case_study_api/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserTokenRepository interface {
+	WithTx(tx *gorm.DB) UserTokenRepository
+	Create(token *entities.UserToken) error
+	GetByHashForUpdate(hash string, purpose string) (*entities.UserToken, error)
+	MarkUsed(id uint, usedAt time.Time) error
+	InvalidateForUser(userID uint, purpose string, usedAt time.Time) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+func (r *userTokenRepository) WithTx(tx *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: tx}
+}
+
+func (r *userTokenRepository) Create(token *entities.UserToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByHashForUpdate loads a token of the given purpose and locks it, so it
+// cannot be redeemed twice by concurrent requests.
+func (r *userTokenRepository) GetByHashForUpdate(hash string, purpose string) (*entities.UserToken, error) {
+	var token entities.UserToken
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND purpose = ?", hash, purpose).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *userTokenRepository) MarkUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&entities.UserToken{}).Where("id = ?", id).Update("used_at", usedAt).Error
+}
+
+// InvalidateForUser uses up every outstanding token of a purpose, so only
+// the most recently mailed one stays valid.
+func (r *userTokenRepository) InvalidateForUser(userID uint, purpose string, usedAt time.Time) error {
+	return r.db.Model(&entities.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error
+}
+
+// DeleteExpired hard deletes tokens that can no longer be redeemed.
+func (r *userTokenRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("expires_at < ?", now).Delete(&entities.UserToken{})
+	return result.RowsAffected, result.Error
+}
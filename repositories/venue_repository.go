@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VenueRepository interface {
+	WithTx(tx *gorm.DB) VenueRepository
+	GetAll() ([]entities.Venue, error)
+	GetByID(id uint) (*entities.Venue, error)
+	GetByIDForUpdate(id uint) (*entities.Venue, error)
+	Create(venue *entities.Venue) error
+	Update(venue *entities.Venue) error
+	Delete(venue *entities.Venue) error
+	GetHallByID(id uint) (*entities.VenueHall, error)
+	CreateHall(hall *entities.VenueHall) error
+	UpdateHall(hall *entities.VenueHall) error
+	DeleteHall(hall *entities.VenueHall) error
+	MaxHallCapacity(venueID uint, excludeHallID uint) (int, error)
+	CountEvents(venueID uint, hallID *uint) (int64, error)
+	MaxActiveEventCapacity(venueID uint, hallID *uint) (int, error)
+}
+
+type venueRepository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) VenueRepository {
+	return &venueRepository{db: db}
+}
+
+func (r *venueRepository) WithTx(tx *gorm.DB) VenueRepository {
+	return &venueRepository{db: tx}
+}
+
+func (r *venueRepository) GetAll() ([]entities.Venue, error) {
+	var venues []entities.Venue
+	err := r.db.Preload("Halls").Order("city ASC, name ASC").Find(&venues).Error
+	return venues, err
+}
+
+func (r *venueRepository) GetByID(id uint) (*entities.Venue, error) {
+	var venue entities.Venue
+	err := r.db.Preload("Halls").First(&venue, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+// GetByIDForUpdate loads a venue and locks its row until the surrounding
+// transaction ends. Scheduling events at the venue takes this lock so two
+// overlapping events can never be saved at the same time.
+func (r *venueRepository) GetByIDForUpdate(id uint) (*entities.Venue, error) {
+	var venue entities.Venue
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&venue, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+func (r *venueRepository) Create(venue *entities.Venue) error {
+	return r.db.Create(venue).Error
+}
+
+// Update saves the venue fields only; halls are managed separately.
+func (r *venueRepository) Update(venue *entities.Venue) error {
+	return r.db.Omit(clause.Associations).Save(venue).Error
+}
+
+func (r *venueRepository) Delete(venue *entities.Venue) error {
+	return r.db.Select("Halls").Delete(venue).Error
+}
+
+func (r *venueRepository) GetHallByID(id uint) (*entities.VenueHall, error) {
+	var hall entities.VenueHall
+	err := r.db.First(&hall, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hall, nil
+}
+
+func (r *venueRepository) CreateHall(hall *entities.VenueHall) error {
+	return r.db.Create(hall).Error
+}
+
+func (r *venueRepository) UpdateHall(hall *entities.VenueHall) error {
+	return r.db.Save(hall).Error
+}
+
+func (r *venueRepository) DeleteHall(hall *entities.VenueHall) error {
+	return r.db.Delete(hall).Error
+}
+
+// MaxHallCapacity returns the capacity of the largest hall of a venue,
+// leaving out excludeHallID.
+func (r *venueRepository) MaxHallCapacity(venueID uint, excludeHallID uint) (int, error) {
+	var capacity int
+	err := r.db.Model(&entities.VenueHall{}).
+		Where("venue_id = ? AND id <> ?", venueID, excludeHallID).
+		Select("COALESCE(MAX(capacity), 0)").
+		Scan(&capacity).Error
+	return capacity, err
+}
+
+// CountEvents counts the events held at a venue, or in one of its halls
+// when hallID is set.
+func (r *venueRepository) CountEvents(venueID uint, hallID *uint) (int64, error) {
+	var count int64
+	query := r.db.Model(&entities.Event{}).Where("venue_id = ?", venueID)
+	if hallID != nil {
+		query = query.Where("hall_id = ?", *hallID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// MaxActiveEventCapacity returns the largest capacity among the upcoming and
+// ongoing events at a venue, or in one of its halls when hallID is set.
+func (r *venueRepository) MaxActiveEventCapacity(venueID uint, hallID *uint) (int, error) {
+	var capacity int
+	query := r.db.Model(&entities.Event{}).
+		Where("venue_id = ? AND status IN ?", venueID, []string{constants.EventStatusUpcoming, constants.EventStatusOngoing})
+	if hallID != nil {
+		query = query.Where("hall_id = ?", *hallID)
+	}
+	err := query.Select("COALESCE(MAX(capacity), 0)").Scan(&capacity).Error
+	return capacity, err
+}
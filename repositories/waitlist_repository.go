@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+
+	"gorm.io/gorm"
+)
+
+type WaitlistDemand struct {
+	Status   string `json:"status"`
+	Entries  int64  `json:"entries"`
+	Quantity int64  `json:"quantity"`
+}
+
+type WaitlistRepository interface {
+	WithTx(tx *gorm.DB) WaitlistRepository
+	Create(entry *entities.WaitlistEntry) error
+	Update(entry *entities.WaitlistEntry) error
+	GetByID(id uint) (*entities.WaitlistEntry, error)
+	GetByUserID(userID uint) ([]entities.WaitlistEntry, error)
+	GetByEventID(eventID uint) ([]entities.WaitlistEntry, error)
+	GetWaitingByEventID(eventID uint) ([]entities.WaitlistEntry, error)
+	GetByHoldID(holdID uint) (*entities.WaitlistEntry, error)
+	ExistsActive(userID, eventID uint) (bool, error)
+	GetPosition(entry *entities.WaitlistEntry) (int64, error)
+	GetDemandByEventID(eventID uint) ([]WaitlistDemand, error)
+	CancelWaitingByEventID(eventID uint) error
+}
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+func (r *waitlistRepository) WithTx(tx *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: tx}
+}
+
+func (r *waitlistRepository) Create(entry *entities.WaitlistEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *waitlistRepository) Update(entry *entities.WaitlistEntry) error {
+	return r.db.Omit("Hold", "User", "Event").Save(entry).Error
+}
+
+func (r *waitlistRepository) GetByID(id uint) (*entities.WaitlistEntry, error) {
+	var entry entities.WaitlistEntry
+	err := r.db.First(&entry, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepository) GetByUserID(userID uint) ([]entities.WaitlistEntry, error) {
+	var entries []entities.WaitlistEntry
+	err := r.db.Preload("Event").Preload("Hold").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *waitlistRepository) GetByEventID(eventID uint) ([]entities.WaitlistEntry, error) {
+	var entries []entities.WaitlistEntry
+	err := r.db.Where("event_id = ?", eventID).Order("id ASC").Find(&entries).Error
+	return entries, err
+}
+
+// GetWaitingByEventID returns the entries still waiting for an event, oldest
+// first, which is the order they are offered freed capacity in.
+func (r *waitlistRepository) GetWaitingByEventID(eventID uint) ([]entities.WaitlistEntry, error) {
+	var entries []entities.WaitlistEntry
+	err := r.db.Where("event_id = ? AND status = ?", eventID, constants.WaitlistStatusWaiting).
+		Order("id ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *waitlistRepository) GetByHoldID(holdID uint) (*entities.WaitlistEntry, error) {
+	var entry entities.WaitlistEntry
+	err := r.db.Where("hold_id = ?", holdID).First(&entry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ExistsActive reports whether a user already waits for, or holds an offer
+// on, an event.
+func (r *waitlistRepository) ExistsActive(userID, eventID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.WaitlistEntry{}).
+		Where("user_id = ? AND event_id = ? AND status IN ?", userID, eventID,
+			[]string{constants.WaitlistStatusWaiting, constants.WaitlistStatusOffered}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetPosition returns the 1-based place of a waiting entry in its event queue.
+func (r *waitlistRepository) GetPosition(entry *entities.WaitlistEntry) (int64, error) {
+	var ahead int64
+	err := r.db.Model(&entities.WaitlistEntry{}).
+		Where("event_id = ? AND status = ? AND id < ?", entry.EventID, constants.WaitlistStatusWaiting, entry.ID).
+		Count(&ahead).Error
+	return ahead + 1, err
+}
+
+func (r *waitlistRepository) GetDemandByEventID(eventID uint) ([]WaitlistDemand, error) {
+	var results []WaitlistDemand
+	err := r.db.Model(&entities.WaitlistEntry{}).
+		Select("status, COUNT(*) as entries, COALESCE(SUM(quantity), 0) as quantity").
+		Where("event_id = ?", eventID).
+		Group("status").
+		Scan(&results).Error
+	return results, err
+}
+
+func (r *waitlistRepository) CancelWaitingByEventID(eventID uint) error {
+	return r.db.Model(&entities.WaitlistEntry{}).
+		Where("event_id = ? AND status = ?", eventID, constants.WaitlistStatusWaiting).
+		UpdateColumn("status", constants.WaitlistStatusCancelled).Error
+}
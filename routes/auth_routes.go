@@ -3,6 +3,7 @@ package routes
 import (
 	"case_study_api/container"
 	"case_study_api/controllers"
+	"case_study_api/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,4 +13,11 @@ func AuthRoutes(rg *gin.RouterGroup, container *container.Container) {
 
 	rg.POST("/register", authController.Register)
 	rg.POST("/login", authController.Login)
+	rg.POST("/refresh", authController.Refresh)
+	rg.POST("/logout", middleware.JWTAuth(container.AuthService), authController.Logout)
+
+	rg.GET("/verify-email", authController.VerifyEmail)
+	rg.POST("/resend-verification", middleware.JWTAuth(container.AuthService), authController.ResendVerification)
+	rg.POST("/forgot-password", authController.ForgotPassword)
+	rg.POST("/reset-password", authController.ResetPassword)
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"case_study_api/container"
+	"case_study_api/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CalendarRoutes(rg *gin.RouterGroup, container *container.Container) {
+	calendarController := controllers.NewCalendarController(container.CalendarService)
+
+	calendar := rg.Group("/calendar")
+	calendar.POST("/feed", calendarController.CreateFeedToken)
+	calendar.DELETE("/feed", calendarController.RevokeFeedToken)
+}
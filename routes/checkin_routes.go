@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"case_study_api/constants"
+	"case_study_api/container"
+	"case_study_api/controllers"
+	"case_study_api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CheckInRoutes(rg *gin.RouterGroup, container *container.Container) {
+	checkInController := controllers.NewCheckInController(container.CheckInService)
+
+	checkIn := rg.Group("/checkin")
+	checkIn.Use(middleware.RequirePermission(constants.PermissionTicketsCheckIn))
+	checkIn.POST("", checkInController.CheckIn)
+	checkIn.POST("/verify", checkInController.VerifyQRPayload)
+}
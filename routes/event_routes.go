@@ -1,20 +1,76 @@
 package routes
 
 import (
+	"case_study_api/constants"
 	"case_study_api/container"
 	"case_study_api/controllers"
 	"case_study_api/middleware"
+	"case_study_api/services"
+	"errors"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 func EventRoutes(rg *gin.RouterGroup, container *container.Container) {
-	eventController := controllers.NewEventController(container.EventService)
+	eventController := controllers.NewEventController(container.EventService, container.CancellationService)
+	tierController := controllers.NewTicketTierController(container.TierService)
+	waitlistController := controllers.NewWaitlistController(container.WaitlistService)
+	refundPolicyController := controllers.NewRefundPolicyController(container.RefundPolicyService)
+	seatMapController := controllers.NewSeatMapController(container.SeatMapService)
+	agendaController := controllers.NewAgendaController(container.AgendaService)
+	calendarController := controllers.NewCalendarController(container.CalendarService)
+
+	owner := eventOwner(container.EventService)
+	canUpdate := middleware.RequirePermissionOrOwn(constants.PermissionEventsUpdate, owner)
 
 	event := rg.Group("/events")
 	event.GET("", eventController.GetEventsPaginated)
 	event.GET("/:id", eventController.GetEventByID)
-	event.POST("", middleware.RoleAuth("admin"), eventController.CreateEvent)
-	event.PUT("/:id", middleware.RoleAuth("admin"), eventController.UpdateEvent)
-	event.DELETE("/:id", middleware.RoleAuth("admin"), eventController.DeleteEvent)
+	event.POST("", middleware.RequirePermission(constants.PermissionEventsCreate), eventController.CreateEvent)
+	event.PUT("/:id", canUpdate, eventController.UpdateEvent)
+	event.DELETE("/:id", middleware.RequirePermissionOrOwn(constants.PermissionEventsDelete, owner), eventController.DeleteEvent)
+	event.PATCH("/:id/status", canUpdate, eventController.UpdateEventStatus)
+	event.GET("/:id/history", canUpdate, eventController.GetEventHistory)
+	event.POST("/:id/cancel", middleware.RequirePermissionOrOwn(constants.PermissionEventsCancel, owner), eventController.CancelEvent)
+	event.GET("/:id/cancellation", canUpdate, eventController.GetCancellationStatus)
+	event.GET("/:id/ical", calendarController.DownloadEventICal)
+
+	event.GET("/:id/tiers", tierController.GetTiers)
+	event.POST("/:id/tiers", canUpdate, tierController.CreateTier)
+	event.PUT("/:id/tiers/:tier_id", canUpdate, tierController.UpdateTier)
+	event.DELETE("/:id/tiers/:tier_id", canUpdate, tierController.DeleteTier)
+
+	event.POST("/:id/waitlist", middleware.RequireVerifiedEmail(container.AuthService), waitlistController.JoinWaitlist)
+	event.GET("/:id/waitlist", canUpdate, waitlistController.GetEventWaitlist)
+
+	event.GET("/:id/refund-policy", refundPolicyController.GetPolicy)
+	event.PUT("/:id/refund-policy", canUpdate, refundPolicyController.UpdatePolicy)
+
+	event.GET("/:id/seats", seatMapController.GetEventSeats)
+	event.PUT("/:id/seat-map", canUpdate, seatMapController.UpdateEventSeatMap)
+
+	event.GET("/:id/sessions", agendaController.GetSessions)
+	event.POST("/:id/sessions", canUpdate, agendaController.CreateSession)
+	event.PUT("/:id/sessions/:session_id", canUpdate, agendaController.UpdateSession)
+	event.DELETE("/:id/sessions/:session_id", canUpdate, agendaController.DeleteSession)
+	event.POST("/:id/sessions/:session_id/register", agendaController.RegisterSession)
+	event.DELETE("/:id/sessions/:session_id/register", agendaController.UnregisterSession)
+
+	event.GET("/:id/speakers", agendaController.GetSpeakers)
+	event.POST("/:id/speakers", canUpdate, agendaController.CreateSpeaker)
+	event.PUT("/:id/speakers/:speaker_id", canUpdate, agendaController.UpdateSpeaker)
+	event.DELETE("/:id/speakers/:speaker_id", canUpdate, agendaController.DeleteSpeaker)
+}
+
+// eventOwner resolves the creator of the event in the :id parameter, for
+// the ":own" event permissions.
+func eventOwner(eventService services.EventService) middleware.OwnerResolver {
+	return func(c *gin.Context) (uint, error) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return 0, errors.New("invalid event id")
+		}
+		return eventService.GetOwnerID(uint(id))
+	}
 }
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"case_study_api/constants"
+	"case_study_api/container"
+	"case_study_api/controllers"
+	"case_study_api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func EventSeriesRoutes(rg *gin.RouterGroup, container *container.Container) {
+	seriesController := controllers.NewEventSeriesController(container.SeriesService)
+
+	series := rg.Group("/event-series")
+	series.GET("", seriesController.GetSeriesList)
+	series.GET("/:id", seriesController.GetSeries)
+	series.POST("", middleware.RequirePermission(constants.PermissionSeriesManage), seriesController.CreateSeries)
+	series.PUT("/:id/occurrences/:event_id", middleware.RequirePermission(constants.PermissionSeriesManage), seriesController.UpdateOccurrence)
+	series.POST("/:id/exceptions", middleware.RequirePermission(constants.PermissionSeriesManage), seriesController.AddException)
+}
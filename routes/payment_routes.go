@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"case_study_api/container"
+	"case_study_api/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PaymentCallbackRoutes(rg *gin.RouterGroup, container *container.Container) {
+	paymentController := controllers.NewPaymentController(container.PaymentService)
+
+	rg.POST("/callback/:gateway", paymentController.HandleCallback)
+}
+
+func OrderRoutes(rg *gin.RouterGroup, container *container.Container) {
+	paymentController := controllers.NewPaymentController(container.PaymentService)
+
+	orders := rg.Group("/orders")
+	orders.GET("", paymentController.GetMyOrders)
+	orders.GET("/:id", paymentController.GetOrder)
+}
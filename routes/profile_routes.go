@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"case_study_api/container"
+	"case_study_api/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ProfileRoutes(rg *gin.RouterGroup, container *container.Container) {
+	profileController := controllers.NewProfileController(container.ProfileService)
+
+	me := rg.Group("/me")
+	me.GET("", profileController.GetProfile)
+	me.PATCH("", profileController.UpdateProfile)
+	me.PUT("/password", profileController.ChangePassword)
+	me.DELETE("", profileController.DeleteAccount)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"case_study_api/constants"
+	"case_study_api/container"
+	"case_study_api/controllers"
+	"case_study_api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PromoCodeRoutes(rg *gin.RouterGroup, container *container.Container) {
+	promoController := controllers.NewPromoCodeController(container.PromoCodeService)
+
+	promo := rg.Group("/promo-codes")
+	promo.Use(middleware.RequirePermission(constants.PermissionPromoCodes))
+	promo.GET("", promoController.GetPromoCodes)
+	promo.GET("/:id", promoController.GetPromoCode)
+	promo.POST("", promoController.CreatePromoCode)
+	promo.PUT("/:id", promoController.UpdatePromoCode)
+	promo.DELETE("/:id", promoController.DeletePromoCode)
+	promo.GET("/:id/redemptions", promoController.GetRedemptionReport)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"case_study_api/container"
+	"case_study_api/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublicRoutes serves read-only pages that need no login.
+func PublicRoutes(rg *gin.RouterGroup, container *container.Container) {
+	agendaController := controllers.NewAgendaController(container.AgendaService)
+	calendarController := controllers.NewCalendarController(container.CalendarService)
+
+	rg.GET("/events/:id/agenda", agendaController.GetAgenda)
+	rg.GET("/calendar/:token", calendarController.GetFeed)
+}
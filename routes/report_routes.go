@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"case_study_api/constants"
 	"case_study_api/container"
 	"case_study_api/controllers"
 	"case_study_api/middleware"
@@ -9,12 +10,14 @@ import (
 )
 
 func ReportRoutes(rg *gin.RouterGroup, container *container.Container) {
-	reportController := controllers.NewReportController(container.ReportService)
+	reportController := controllers.NewReportController(container.ReportService, container.LifecycleService)
 
 	report := rg.Group("/reports")
-	report.Use(middleware.RoleAuth("admin"))
+	report.Use(middleware.RequirePermission(constants.PermissionReportsRead))
 	report.GET("/summary", reportController.SummaryReport)
 	report.GET("/event/:id", reportController.EventReport)
+	report.GET("/series/:id", reportController.SeriesReport)
 	report.GET("/system", reportController.SystemReport)
 	report.GET("/system/pdf", reportController.SystemReportPDF)
+	report.GET("/lifecycle", reportController.LifecycleReport)
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"case_study_api/constants"
+	"case_study_api/container"
+	"case_study_api/controllers"
+	"case_study_api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AdminRoleRoutes(rg *gin.RouterGroup, container *container.Container) {
+	roleController := controllers.NewRoleController(container.RoleService)
+
+	roles := rg.Group("/admin/roles")
+	roles.Use(middleware.RequirePermission(constants.PermissionRolesManage))
+	roles.GET("", roleController.GetRoles)
+	roles.GET("/:id", roleController.GetRole)
+	roles.POST("", roleController.CreateRole)
+	roles.PUT("/:id", roleController.UpdateRole)
+	roles.DELETE("/:id", roleController.DeleteRole)
+
+	rg.GET("/admin/permissions", middleware.RequirePermission(constants.PermissionRolesManage), roleController.GetPermissions)
+}
@@ -11,10 +11,27 @@ func RegisterRoutes(r *gin.Engine, container *container.Container) {
 	auth := r.Group("/auth")
 	AuthRoutes(auth, container)
 
+	payments := r.Group("/payments")
+	PaymentCallbackRoutes(payments, container)
+
+	public := r.Group("/public")
+	PublicRoutes(public, container)
+
 	api := r.Group("/api")
-	api.Use(middleware.JWTAuth())
+	api.Use(middleware.JWTAuth(container.AuthService), middleware.LoadPermissions(container.RoleService))
 
 	EventRoutes(api, container)
 	TicketRoutes(api, container)
 	ReportRoutes(api, container)
+	CheckInRoutes(api, container)
+	WaitlistRoutes(api, container)
+	OrderRoutes(api, container)
+	PromoCodeRoutes(api, container)
+	VenueRoutes(api, container)
+	EventSeriesRoutes(api, container)
+	CalendarRoutes(api, container)
+	SettingRoutes(api, container)
+	ProfileRoutes(api, container)
+	AdminUserRoutes(api, container)
+	AdminRoleRoutes(api, container)
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"case_study_api/constants"
+	"case_study_api/container"
+	"case_study_api/controllers"
+	"case_study_api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SettingRoutes(rg *gin.RouterGroup, container *container.Container) {
+	settingController := controllers.NewSettingController(container.SettingService)
+
+	settings := rg.Group("/settings")
+	settings.Use(middleware.RequirePermission(constants.PermissionSettingsManage))
+	settings.GET("", settingController.GetSettings)
+	settings.PUT("", settingController.UpdateSettings)
+}
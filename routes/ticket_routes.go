@@ -3,6 +3,7 @@ package routes
 import (
 	"case_study_api/container"
 	"case_study_api/controllers"
+	"case_study_api/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,9 +11,17 @@ import (
 func TicketRoutes(rg *gin.RouterGroup, container *container.Container) {
 	ticketController := controllers.NewTicketController(container.TicketService)
 
+	requireVerifiedEmail := middleware.RequireVerifiedEmail(container.AuthService)
+
 	ticket := rg.Group("/tickets")
 	ticket.GET("", ticketController.GetTicketsPaginated)
 	ticket.GET("/:id", ticketController.GetTicket)
-	ticket.POST("", ticketController.BookTicket)
+	ticket.GET("/:id/qr", ticketController.GetTicketQRCode)
+	ticket.GET("/:id/pdf", ticketController.DownloadTicketPDF)
+	ticket.POST("", requireVerifiedEmail, ticketController.BookTicket)
 	ticket.PATCH("/:id", ticketController.CancelTicket)
+
+	ticket.POST("/holds", requireVerifiedEmail, ticketController.HoldTicket)
+	ticket.POST("/holds/:token/confirm", requireVerifiedEmail, ticketController.ConfirmHold)
+	ticket.DELETE("/holds/:token", ticketController.ReleaseHold)
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"case_study_api/constants"
+	"case_study_api/container"
+	"case_study_api/controllers"
+	"case_study_api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AdminUserRoutes(rg *gin.RouterGroup, container *container.Container) {
+	userController := controllers.NewUserController(container.UserService)
+
+	users := rg.Group("/admin/users")
+	users.Use(middleware.RequirePermission(constants.PermissionUsersManage))
+	users.GET("", userController.GetUsers)
+	users.GET("/:id", userController.GetUser)
+	users.PATCH("/:id/role", userController.UpdateRole)
+	users.POST("/:id/suspend", userController.SuspendUser)
+	users.DELETE("/:id/suspend", userController.UnsuspendUser)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"case_study_api/constants"
+	"case_study_api/container"
+	"case_study_api/controllers"
+	"case_study_api/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func VenueRoutes(rg *gin.RouterGroup, container *container.Container) {
+	venueController := controllers.NewVenueController(container.VenueService)
+	seatMapController := controllers.NewSeatMapController(container.SeatMapService)
+
+	venue := rg.Group("/venues")
+	venue.GET("", venueController.GetVenues)
+	venue.GET("/:id", venueController.GetVenue)
+	venue.POST("", middleware.RequirePermission(constants.PermissionVenuesManage), venueController.CreateVenue)
+	venue.PUT("/:id", middleware.RequirePermission(constants.PermissionVenuesManage), venueController.UpdateVenue)
+	venue.DELETE("/:id", middleware.RequirePermission(constants.PermissionVenuesManage), venueController.DeleteVenue)
+
+	venue.POST("/:id/halls", middleware.RequirePermission(constants.PermissionVenuesManage), venueController.CreateHall)
+	venue.PUT("/:id/halls/:hall_id", middleware.RequirePermission(constants.PermissionVenuesManage), venueController.UpdateHall)
+	venue.DELETE("/:id/halls/:hall_id", middleware.RequirePermission(constants.PermissionVenuesManage), venueController.DeleteHall)
+
+	venue.GET("/:id/seat-map", seatMapController.GetVenueSeatMap)
+	venue.PUT("/:id/seat-map", middleware.RequirePermission(constants.PermissionVenuesManage), seatMapController.UpdateVenueSeatMap)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"case_study_api/container"
+	"case_study_api/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WaitlistRoutes(rg *gin.RouterGroup, container *container.Container) {
+	waitlistController := controllers.NewWaitlistController(container.WaitlistService)
+
+	waitlist := rg.Group("/waitlist")
+	waitlist.GET("", waitlistController.GetMyWaitlist)
+	waitlist.DELETE("/:id", waitlistController.LeaveWaitlist)
+}
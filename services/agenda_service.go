@@ -0,0 +1,546 @@
+package services
+
+import (
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AgendaService interface {
+	GetAgenda(eventID uint) (*dto.AgendaResponse, error)
+	GetSessions(eventID uint, userID uint) ([]dto.SessionResponse, error)
+	CreateSession(eventID uint, req dto.CreateSessionRequest) (*dto.SessionResponse, error)
+	UpdateSession(eventID uint, sessionID uint, req dto.UpdateSessionRequest) (*dto.SessionResponse, error)
+	DeleteSession(eventID uint, sessionID uint) error
+	GetSpeakers(eventID uint) ([]dto.SpeakerResponse, error)
+	CreateSpeaker(eventID uint, req dto.CreateSpeakerRequest) (*dto.SpeakerResponse, error)
+	UpdateSpeaker(eventID uint, speakerID uint, req dto.UpdateSpeakerRequest) (*dto.SpeakerResponse, error)
+	DeleteSpeaker(eventID uint, speakerID uint) error
+	Register(eventID uint, sessionID uint, userID uint) (*dto.SessionResponse, error)
+	Unregister(eventID uint, sessionID uint, userID uint) error
+}
+
+type agendaService struct {
+	txManager   repositories.TxManager
+	sessionRepo repositories.SessionRepository
+	speakerRepo repositories.SpeakerRepository
+	eventRepo   repositories.EventRepository
+	ticketRepo  repositories.TicketRepository
+}
+
+func NewAgendaService(txManager repositories.TxManager, sessionRepo repositories.SessionRepository, speakerRepo repositories.SpeakerRepository, eventRepo repositories.EventRepository, ticketRepo repositories.TicketRepository) AgendaService {
+	return &agendaService{
+		txManager:   txManager,
+		sessionRepo: sessionRepo,
+		speakerRepo: speakerRepo,
+		eventRepo:   eventRepo,
+		ticketRepo:  ticketRepo,
+	}
+}
+
+// GetAgenda returns the programme of an event with its sessions grouped by
+// the day they start on.
+func (s *agendaService) GetAgenda(eventID uint) (*dto.AgendaResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	sessions, err := s.sessionRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	speakers, err := s.speakerRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.AgendaResponse{
+		EventID:  event.ID,
+		Title:    event.Title,
+		Location: event.Location,
+		Date:     event.Date.Format("2006-01-02T15:04:05Z"),
+		EndDate:  event.EndDate.Format("2006-01-02T15:04:05Z"),
+		Days:     make([]dto.AgendaDayResponse, 0),
+		Speakers: make([]dto.SpeakerResponse, 0, len(speakers)),
+	}
+
+	// Sessions come sorted by start time, so each day is a contiguous run
+	for _, session := range sessions {
+		day := session.StartTime.Format("2006-01-02")
+		if len(response.Days) == 0 || response.Days[len(response.Days)-1].Date != day {
+			response.Days = append(response.Days, dto.AgendaDayResponse{Date: day})
+		}
+		current := &response.Days[len(response.Days)-1]
+		current.Sessions = append(current.Sessions, s.sessionToResponse(session, false))
+	}
+
+	for _, speaker := range speakers {
+		response.Speakers = append(response.Speakers, s.speakerToResponse(speaker))
+	}
+
+	return &response, nil
+}
+
+// GetSessions lists the sessions of an event, marking those userID is
+// registered for.
+func (s *agendaService) GetSessions(eventID uint, userID uint) ([]dto.SessionResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	sessions, err := s.sessionRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	registeredIDs, err := s.sessionRepo.GetRegisteredSessionIDs(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	registered := make(map[uint]bool, len(registeredIDs))
+	for _, id := range registeredIDs {
+		registered[id] = true
+	}
+
+	sessionResponses := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionResponses = append(sessionResponses, s.sessionToResponse(session, registered[session.ID]))
+	}
+
+	return sessionResponses, nil
+}
+
+func (s *agendaService) CreateSession(eventID uint, req dto.CreateSessionRequest) (*dto.SessionResponse, error) {
+	startTime, err := time.Parse("2006-01-02T15:04:05Z", req.StartTime)
+	if err != nil {
+		return nil, errors.New("invalid start time format")
+	}
+	endTime, err := time.Parse("2006-01-02T15:04:05Z", req.EndTime)
+	if err != nil {
+		return nil, errors.New("invalid end time format")
+	}
+
+	session := entities.Session{
+		EventID:     eventID,
+		Title:       req.Title,
+		Description: req.Description,
+		Room:        req.Room,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		Capacity:    req.Capacity,
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		// Lock the event so sessions sharing a room are checked one at a time
+		event, err := s.eventRepo.WithTx(tx).GetByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		if err := s.checkSession(s.sessionRepo.WithTx(tx), event, &session); err != nil {
+			return err
+		}
+
+		if len(req.SpeakerIDs) > 0 {
+			session.Speakers, err = s.eventSpeakers(s.speakerRepo.WithTx(tx), eventID, req.SpeakerIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.sessionRepo.WithTx(tx).Create(&session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.sessionToResponse(session, false)
+	return &response, nil
+}
+
+func (s *agendaService) UpdateSession(eventID uint, sessionID uint, req dto.UpdateSessionRequest) (*dto.SessionResponse, error) {
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		sessionRepo := s.sessionRepo.WithTx(tx)
+
+		event, err := s.eventRepo.WithTx(tx).GetByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		session, err := sessionRepo.GetByIDForUpdate(sessionID)
+		if err != nil || session.EventID != event.ID {
+			return errors.New("session not found")
+		}
+
+		// Update fields if provided
+		if req.Title != "" {
+			session.Title = req.Title
+		}
+		if req.Description != "" {
+			session.Description = req.Description
+		}
+		if req.Room != "" {
+			session.Room = req.Room
+		}
+		if req.StartTime != "" {
+			startTime, err := time.Parse("2006-01-02T15:04:05Z", req.StartTime)
+			if err != nil {
+				return errors.New("invalid start time format")
+			}
+			session.StartTime = startTime
+		}
+		if req.EndTime != "" {
+			endTime, err := time.Parse("2006-01-02T15:04:05Z", req.EndTime)
+			if err != nil {
+				return errors.New("invalid end time format")
+			}
+			session.EndTime = endTime
+		}
+		if req.Capacity > 0 {
+			if req.Capacity < session.Registered {
+				return fmt.Errorf("capacity cannot be lower than the %d registered attendees", session.Registered)
+			}
+			session.Capacity = req.Capacity
+		}
+
+		if err := s.checkSession(sessionRepo, event, session); err != nil {
+			return err
+		}
+
+		var speakers []entities.Speaker
+		if req.SpeakerIDs != nil {
+			speakers = []entities.Speaker{}
+			if len(req.SpeakerIDs) > 0 {
+				speakers, err = s.eventSpeakers(s.speakerRepo.WithTx(tx), eventID, req.SpeakerIDs)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return sessionRepo.Update(session, speakers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.sessionToResponse(*session, false)
+	return &response, nil
+}
+
+func (s *agendaService) DeleteSession(eventID uint, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.EventID != eventID {
+		return errors.New("session not found")
+	}
+
+	if session.Registered > 0 {
+		return errors.New("cannot delete session with registered attendees")
+	}
+
+	return s.sessionRepo.Delete(session)
+}
+
+func (s *agendaService) GetSpeakers(eventID uint) ([]dto.SpeakerResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	speakers, err := s.speakerRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	speakerResponses := make([]dto.SpeakerResponse, 0, len(speakers))
+	for _, speaker := range speakers {
+		speakerResponses = append(speakerResponses, s.speakerToResponse(speaker))
+	}
+
+	return speakerResponses, nil
+}
+
+func (s *agendaService) CreateSpeaker(eventID uint, req dto.CreateSpeakerRequest) (*dto.SpeakerResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	speaker := entities.Speaker{
+		EventID:  eventID,
+		Name:     req.Name,
+		Headline: req.Headline,
+		Company:  req.Company,
+		Bio:      req.Bio,
+		PhotoURL: req.PhotoURL,
+	}
+	if err := s.speakerRepo.Create(&speaker); err != nil {
+		return nil, err
+	}
+
+	response := s.speakerToResponse(speaker)
+	return &response, nil
+}
+
+func (s *agendaService) UpdateSpeaker(eventID uint, speakerID uint, req dto.UpdateSpeakerRequest) (*dto.SpeakerResponse, error) {
+	speaker, err := s.speakerRepo.GetByID(speakerID)
+	if err != nil || speaker.EventID != eventID {
+		return nil, errors.New("speaker not found")
+	}
+
+	// Update fields if provided
+	if req.Name != "" {
+		speaker.Name = req.Name
+	}
+	if req.Headline != "" {
+		speaker.Headline = req.Headline
+	}
+	if req.Company != "" {
+		speaker.Company = req.Company
+	}
+	if req.Bio != "" {
+		speaker.Bio = req.Bio
+	}
+	if req.PhotoURL != "" {
+		speaker.PhotoURL = req.PhotoURL
+	}
+
+	if err := s.speakerRepo.Update(speaker); err != nil {
+		return nil, err
+	}
+
+	response := s.speakerToResponse(*speaker)
+	return &response, nil
+}
+
+func (s *agendaService) DeleteSpeaker(eventID uint, speakerID uint) error {
+	speaker, err := s.speakerRepo.GetByID(speakerID)
+	if err != nil || speaker.EventID != eventID {
+		return errors.New("speaker not found")
+	}
+
+	return s.speakerRepo.Delete(speaker)
+}
+
+// Register signs a ticket holder up for a session. Sessions the user is
+// already attending at the same time are refused, as is a full session.
+func (s *agendaService) Register(eventID uint, sessionID uint, userID uint) (*dto.SessionResponse, error) {
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		sessionRepo := s.sessionRepo.WithTx(tx)
+
+		session, err := sessionRepo.GetByIDForUpdate(sessionID)
+		if err != nil || session.EventID != eventID {
+			return errors.New("session not found")
+		}
+
+		event, err := s.eventRepo.WithTx(tx).GetByID(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+		if isEventClosed(event) {
+			return errors.New("event is not available")
+		}
+		if !time.Now().Before(session.StartTime) {
+			return errors.New("session has already started")
+		}
+
+		hasTicket, err := s.ticketRepo.WithTx(tx).HasValidTicket(userID, eventID)
+		if err != nil {
+			return err
+		}
+		if !hasTicket {
+			return errors.New("a paid ticket for the event is required to register")
+		}
+
+		registered, err := sessionRepo.IsRegistered(session.ID, userID)
+		if err != nil {
+			return err
+		}
+		if registered {
+			return errors.New("already registered for this session")
+		}
+
+		conflict, err := sessionRepo.FindRegistrationConflict(eventID, userID, session.StartTime, session.EndTime)
+		if err != nil {
+			return err
+		}
+		if conflict != nil {
+			return fmt.Errorf("already registered for %q at that time", conflict.Title)
+		}
+
+		if err := sessionRepo.IncrementRegistered(session.ID); err != nil {
+			return err
+		}
+
+		return sessionRepo.CreateRegistration(&entities.SessionRegistration{
+			SessionID: session.ID,
+			UserID:    userID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.sessionToResponse(*session, true)
+	return &response, nil
+}
+
+// releaseSessionPlaces gives back every session place a user holds at an
+// event once they have no booked or used ticket left for it. Callers run it
+// in the transaction that cancelled the ticket, with repositories bound to
+// it.
+func releaseSessionPlaces(sessionRepo repositories.SessionRepository, ticketRepo repositories.TicketRepository, eventID uint, userID uint) error {
+	attending, err := ticketRepo.HasValidTicket(userID, eventID)
+	if err != nil || attending {
+		return err
+	}
+
+	sessionIDs, err := sessionRepo.GetRegisteredSessionIDs(eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		deleted, err := sessionRepo.DeleteRegistration(sessionID, userID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			continue
+		}
+		if err := sessionRepo.DecrementRegistered(sessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Unregister gives the user's place in a session back.
+func (s *agendaService) Unregister(eventID uint, sessionID uint, userID uint) error {
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		sessionRepo := s.sessionRepo.WithTx(tx)
+
+		session, err := sessionRepo.GetByIDForUpdate(sessionID)
+		if err != nil || session.EventID != eventID {
+			return errors.New("session not found")
+		}
+
+		if !time.Now().Before(session.StartTime) {
+			return errors.New("session has already started")
+		}
+
+		deleted, err := sessionRepo.DeleteRegistration(session.ID, userID)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			return errors.New("not registered for this session")
+		}
+
+		return sessionRepo.DecrementRegistered(session.ID)
+	})
+}
+
+// checkSession validates a session against its event: it must fit within
+// the event dates and capacity, and its room must be free at that time.
+func (s *agendaService) checkSession(sessionRepo repositories.SessionRepository, event *entities.Event, session *entities.Session) error {
+	if isEventClosed(event) {
+		return errors.New("event is not available")
+	}
+
+	if !session.EndTime.After(session.StartTime) {
+		return errors.New("end time must be after start time")
+	}
+	if session.StartTime.Before(event.Date) {
+		return errors.New("session cannot start before the event")
+	}
+	if !event.EndDate.Before(event.Date) && session.EndTime.After(event.EndDate) {
+		return errors.New("session cannot end after the event")
+	}
+
+	if session.Capacity > event.Capacity {
+		return fmt.Errorf("session capacity exceeds the event capacity of %d", event.Capacity)
+	}
+
+	if session.Room == "" {
+		return nil
+	}
+	conflict, err := sessionRepo.FindRoomConflict(event.ID, session.Room, session.StartTime, session.EndTime, session.ID)
+	if err != nil {
+		return err
+	}
+	if conflict != nil {
+		return fmt.Errorf("room %q is already used by %q at that time", session.Room, conflict.Title)
+	}
+
+	return nil
+}
+
+// eventSpeakers loads the speakers with the given IDs, which must all
+// belong to the event.
+func (s *agendaService) eventSpeakers(speakerRepo repositories.SpeakerRepository, eventID uint, ids []uint) ([]entities.Speaker, error) {
+	speakers, err := speakerRepo.GetByIDs(eventID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		unique[id] = true
+	}
+	if len(speakers) != len(unique) {
+		return nil, errors.New("speaker not found for this event")
+	}
+
+	return speakers, nil
+}
+
+func (s *agendaService) sessionToResponse(session entities.Session, isRegistered bool) dto.SessionResponse {
+	speakers := make([]dto.SpeakerResponse, 0, len(session.Speakers))
+	for _, speaker := range session.Speakers {
+		speakers = append(speakers, s.speakerToResponse(speaker))
+	}
+
+	return dto.SessionResponse{
+		ID:           session.ID,
+		EventID:      session.EventID,
+		Title:        session.Title,
+		Description:  session.Description,
+		Room:         session.Room,
+		StartTime:    session.StartTime.Format("2006-01-02T15:04:05Z"),
+		EndTime:      session.EndTime.Format("2006-01-02T15:04:05Z"),
+		Capacity:     session.Capacity,
+		Registered:   session.Registered,
+		Available:    session.Capacity - session.Registered,
+		IsRegistered: isRegistered,
+		Speakers:     speakers,
+	}
+}
+
+func (s *agendaService) speakerToResponse(speaker entities.Speaker) dto.SpeakerResponse {
+	return dto.SpeakerResponse{
+		ID:       speaker.ID,
+		EventID:  speaker.EventID,
+		Name:     speaker.Name,
+		Headline: speaker.Headline,
+		Company:  speaker.Company,
+		Bio:      speaker.Bio,
+		PhotoURL: speaker.PhotoURL,
+	}
+}
@@ -1,28 +1,71 @@
 package services
 
 import (
+	"case_study_api/constants"
 	"case_study_api/dto"
 	"case_study_api/entities"
+	"case_study_api/mailer"
 	"case_study_api/repositories"
 	"case_study_api/utils"
 	"errors"
+	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	errInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	errInvalidUserToken    = errors.New("invalid or expired token")
 )
 
 type AuthService interface {
 	Register(req dto.RegisterRequest) (*dto.AuthResponse, error)
 	Login(req dto.LoginRequest) (*dto.AuthResponse, error)
+	Refresh(req dto.RefreshTokenRequest) (*dto.AuthResponse, error)
+	Logout(userID uint, tokenID string, tokenExpiresAt time.Time, req dto.LogoutRequest) error
+	IsTokenRevoked(tokenID string) (bool, error)
+	GetUserAccess(userID uint) (string, bool, error)
+	PurgeExpiredTokens() (int64, error)
+	VerifyEmail(token string) error
+	ResendVerification(userID uint) error
+	ForgotPassword(req dto.ForgotPasswordRequest) error
+	ResetPassword(req dto.ResetPasswordRequest) error
+	MustVerifyEmail(userID uint) (bool, error)
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
+	txManager            repositories.TxManager
+	userRepo             repositories.UserRepository
+	refreshRepo          repositories.RefreshTokenRepository
+	revokedRepo          repositories.RevokedTokenRepository
+	userTokenRepo        repositories.UserTokenRepository
+	settingService       SettingService
+	mailer               mailer.Mailer
+	accessTokenTTL       time.Duration
+	refreshTokenTTL      time.Duration
+	emailVerificationTTL time.Duration
+	passwordResetTTL     time.Duration
+	baseURL              string
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+func NewAuthService(txManager repositories.TxManager, userRepo repositories.UserRepository, refreshRepo repositories.RefreshTokenRepository, revokedRepo repositories.RevokedTokenRepository, userTokenRepo repositories.UserTokenRepository, settingService SettingService, mailClient mailer.Mailer, accessTokenTTL time.Duration, refreshTokenTTL time.Duration, emailVerificationTTL time.Duration, passwordResetTTL time.Duration, baseURL string) AuthService {
 	return &authService{
-		userRepo: userRepo,
+		txManager:            txManager,
+		userRepo:             userRepo,
+		refreshRepo:          refreshRepo,
+		revokedRepo:          revokedRepo,
+		userTokenRepo:        userTokenRepo,
+		settingService:       settingService,
+		mailer:               mailClient,
+		accessTokenTTL:       accessTokenTTL,
+		refreshTokenTTL:      refreshTokenTTL,
+		emailVerificationTTL: emailVerificationTTL,
+		passwordResetTTL:     passwordResetTTL,
+		baseURL:              baseURL,
 	}
 }
 
@@ -52,18 +95,13 @@ func (s *authService) Register(req dto.RegisterRequest) (*dto.AuthResponse, erro
 		return nil, err
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID, user.Role)
-	if err != nil {
-		return nil, errors.New("failed to generate token")
+	// A mail failure must not fail the registration; the user can ask for
+	// the verification mail again
+	if err := s.sendVerificationEmail(&user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
 	}
 
-	return &dto.AuthResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		Token: token,
-	}, nil
+	return s.startSession(&user)
 }
 
 func (s *authService) Login(req dto.LoginRequest) (*dto.AuthResponse, error) {
@@ -78,16 +116,338 @@ func (s *authService) Login(req dto.LoginRequest) (*dto.AuthResponse, error) {
 		return nil, errors.New("invalid credentials")
 	}
 
+	if user.SuspendedAt != nil {
+		return nil, errors.New("account is suspended")
+	}
+
+	return s.startSession(user)
+}
+
+// Refresh rotates a refresh token: it is used up and a new one of the same
+// family is issued with a fresh access token. A token that was already
+// rotated can only come from a copy, so the whole family is revoked and
+// its holder, legitimate or not, has to log in again.
+func (s *authService) Refresh(req dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
+	now := time.Now()
+	var response *dto.AuthResponse
+	reused := false
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		refreshRepo := s.refreshRepo.WithTx(tx)
+
+		token, err := refreshRepo.GetByHashForUpdate(utils.HashToken(req.RefreshToken))
+		if err != nil {
+			return errInvalidRefreshToken
+		}
+		if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+			return errInvalidRefreshToken
+		}
+		if token.UsedAt != nil {
+			reused = true
+			return refreshRepo.RevokeFamily(token.FamilyID, now)
+		}
+
+		// The role is read again so role changes apply from the next refresh
+		user, err := s.userRepo.FindByID(token.UserID)
+		if err != nil || user.SuspendedAt != nil {
+			return errInvalidRefreshToken
+		}
+
+		if err := refreshRepo.MarkUsed(token.ID, now); err != nil {
+			return err
+		}
+
+		response, err = s.issueTokens(refreshRepo, user, token.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, errors.New("refresh token reuse detected, please log in again")
+	}
+
+	return response, nil
+}
+
+// Logout revokes the access token used for the request and the refresh
+// tokens of the session, or of every session of the user.
+func (s *authService) Logout(userID uint, tokenID string, tokenExpiresAt time.Time, req dto.LogoutRequest) error {
+	now := time.Now()
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		refreshRepo := s.refreshRepo.WithTx(tx)
+
+		err := s.revokedRepo.WithTx(tx).Create(&entities.RevokedToken{
+			JTI:       tokenID,
+			UserID:    userID,
+			ExpiresAt: tokenExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if req.AllSessions {
+			return refreshRepo.RevokeAllForUser(userID, now)
+		}
+		if req.RefreshToken == "" {
+			return nil
+		}
+
+		// An unknown refresh token cannot be used anyway, so logout still succeeds
+		token, err := refreshRepo.GetByHashForUpdate(utils.HashToken(req.RefreshToken))
+		if err != nil || token.UserID != userID {
+			return nil
+		}
+		return refreshRepo.RevokeFamily(token.FamilyID, now)
+	})
+}
+
+func (s *authService) IsTokenRevoked(tokenID string) (bool, error) {
+	return s.revokedRepo.Exists(tokenID)
+}
+
+// GetUserAccess returns the user's current role and whether the user may no
+// longer use the API. Deleted accounts count as suspended for good.
+func (s *authService) GetUserAccess(userID uint) (string, bool, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", true, nil
+		}
+		return "", false, err
+	}
+	return user.Role, user.SuspendedAt != nil || user.AnonymizedAt != nil, nil
+}
+
+// PurgeExpiredTokens deletes refresh tokens and revocation entries that
+// have expired and returns how many were removed.
+func (s *authService) PurgeExpiredTokens() (int64, error) {
+	now := time.Now()
+
+	refreshTokens, err := s.refreshRepo.DeleteExpired(now)
+	if err != nil {
+		return 0, err
+	}
+
+	revokedTokens, err := s.revokedRepo.DeleteExpired(now)
+	if err != nil {
+		return refreshTokens, err
+	}
+
+	userTokens, err := s.userTokenRepo.DeleteExpired(now)
+	if err != nil {
+		return refreshTokens + revokedTokens, err
+	}
+
+	return refreshTokens + revokedTokens + userTokens, nil
+}
+
+func (s *authService) VerifyEmail(token string) error {
+	now := time.Now()
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		userToken, err := s.redeemUserToken(tx, token, constants.TokenPurposeVerifyEmail, now)
+		if err != nil {
+			return err
+		}
+		return s.userRepo.WithTx(tx).MarkEmailVerified(userToken.UserID, now)
+	})
+}
+
+func (s *authService) ResendVerification(userID uint) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+	if user.EmailVerifiedAt != nil {
+		return errors.New("email is already verified")
+	}
+
+	if err := s.sendVerificationEmail(user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+		return errors.New("failed to send verification email")
+	}
+	return nil
+}
+
+// ForgotPassword mails a password reset token. It succeeds whether or not
+// the email belongs to an account, so it cannot be used to probe for
+// registered addresses.
+func (s *authService) ForgotPassword(req dto.ForgotPasswordRequest) error {
+	user, err := s.userRepo.FindByEmail(strings.ToLower(req.Email))
+	if err != nil || user == nil || user.ID == 0 {
+		return nil
+	}
+
+	token, err := s.createUserToken(user.ID, constants.TokenPurposeResetPassword, s.passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	err = s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"We received a request to reset your password. Use this token to choose a new one:\n\n%s\n\n"+
+			"The token expires in %s. If you did not ask for a reset, you can ignore this email.\n",
+			user.Name, token, s.passwordResetTTL),
+	})
+	if err != nil {
+		log.Printf("Failed to send password reset email to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and signs the user
+// out of every session. Access tokens already issued stay valid until they
+// expire.
+func (s *authService) ResetPassword(req dto.ResetPasswordRequest) error {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+	now := time.Now()
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		userToken, err := s.redeemUserToken(tx, req.Token, constants.TokenPurposeResetPassword, now)
+		if err != nil {
+			return err
+		}
+
+		userRepo := s.userRepo.WithTx(tx)
+		if err := userRepo.UpdatePassword(userToken.UserID, string(hashed)); err != nil {
+			return err
+		}
+		// The reset token was mailed to the address, which proves it works
+		if err := userRepo.MarkEmailVerified(userToken.UserID, now); err != nil {
+			return err
+		}
+		if err := s.userTokenRepo.WithTx(tx).InvalidateForUser(userToken.UserID, constants.TokenPurposeResetPassword, now); err != nil {
+			return err
+		}
+		return s.refreshRepo.WithTx(tx).RevokeAllForUser(userToken.UserID, now)
+	})
+}
+
+// MustVerifyEmail reports whether the user is blocked from booking until
+// they verify their email address.
+func (s *authService) MustVerifyEmail(userID uint) (bool, error) {
+	required, err := s.settingService.RequiresEmailVerification()
+	if err != nil || !required {
+		return false, err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return false, err
+	}
+	return user.EmailVerifiedAt == nil, nil
+}
+
+func (s *authService) sendVerificationEmail(user *entities.User) error {
+	token, err := s.createUserToken(user.ID, constants.TokenPurposeVerifyEmail, s.emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm your email address by opening this link:\n\n%s/auth/verify-email?token=%s\n\n"+
+			"The link expires in %s.\n",
+			user.Name, s.baseURL, token, s.emailVerificationTTL),
+	})
+}
+
+// createUserToken issues a new token of the given purpose and invalidates
+// the ones mailed before it. It returns the raw token to be mailed.
+func (s *authService) createUserToken(userID uint, purpose string, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", errors.New("failed to generate token")
+	}
+	now := time.Now()
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		userTokenRepo := s.userTokenRepo.WithTx(tx)
+
+		if err := userTokenRepo.InvalidateForUser(userID, purpose, now); err != nil {
+			return err
+		}
+		return userTokenRepo.Create(&entities.UserToken{
+			UserID:    userID,
+			Purpose:   purpose,
+			TokenHash: utils.HashToken(token),
+			ExpiresAt: now.Add(ttl),
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// redeemUserToken uses up a mailed token inside tx and returns it.
+func (s *authService) redeemUserToken(tx *gorm.DB, token string, purpose string, now time.Time) (*entities.UserToken, error) {
+	userTokenRepo := s.userTokenRepo.WithTx(tx)
+
+	userToken, err := userTokenRepo.GetByHashForUpdate(utils.HashToken(token), purpose)
+	if err != nil {
+		return nil, errInvalidUserToken
+	}
+	if userToken.UsedAt != nil || !now.Before(userToken.ExpiresAt) {
+		return nil, errInvalidUserToken
+	}
+
+	if err := userTokenRepo.MarkUsed(userToken.ID, now); err != nil {
+		return nil, err
+	}
+	return userToken, nil
+}
+
+// startSession issues the tokens of a new login, starting a new refresh
+// token family.
+func (s *authService) startSession(user *entities.User) (*dto.AuthResponse, error) {
+	familyID, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	return s.issueTokens(s.refreshRepo, user, familyID)
+}
+
+func (s *authService) issueTokens(refreshRepo repositories.RefreshTokenRepository, user *entities.User, familyID string) (*dto.AuthResponse, error) {
 	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID, user.Role)
+	token, err := utils.GenerateJWT(user.ID, user.Role, s.accessTokenTTL)
+	if err != nil {
+		return nil, errors.New("failed to generate token")
+	}
+
+	refreshToken, err := utils.GenerateSecureToken(32)
 	if err != nil {
 		return nil, errors.New("failed to generate token")
 	}
 
+	err = refreshRepo.Create(&entities.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(refreshToken),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return &dto.AuthResponse{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		Token: token,
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Token:         token,
+		RefreshToken:  refreshToken,
+		ExpiresIn:     int(s.accessTokenTTL.Seconds()),
+		EmailVerified: user.EmailVerifiedAt != nil,
 	}, nil
 }
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"case_study_api/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type CalendarService interface {
+	GetEventCalendar(eventID uint) ([]byte, error)
+	GetFeed(token string) ([]byte, error)
+	CreateFeedToken(userID uint) (*dto.CalendarFeedResponse, error)
+	RevokeFeedToken(userID uint) error
+}
+
+type calendarService struct {
+	userRepo   repositories.UserRepository
+	eventRepo  repositories.EventRepository
+	ticketRepo repositories.TicketRepository
+	baseURL    string
+}
+
+func NewCalendarService(userRepo repositories.UserRepository, eventRepo repositories.EventRepository, ticketRepo repositories.TicketRepository, baseURL string) CalendarService {
+	return &calendarService{
+		userRepo:   userRepo,
+		eventRepo:  eventRepo,
+		ticketRepo: ticketRepo,
+		baseURL:    baseURL,
+	}
+}
+
+func (s *calendarService) GetEventCalendar(eventID uint) ([]byte, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	return utils.BuildICalendar(event.Title, []utils.ICalEvent{eventToICal(*event)}, time.Now()), nil
+}
+
+// GetFeed renders the personal calendar of the user owning token: one
+// VEVENT per event they hold tickets for. Events whose tickets were all
+// cancelled, and cancelled events, stay in the feed marked as cancelled so
+// subscribed calendars update them instead of keeping stale entries.
+func (s *calendarService) GetFeed(token string) ([]byte, error) {
+	user, err := s.userRepo.FindByCalendarTokenHash(utils.HashToken(token))
+	if err != nil {
+		return nil, errors.New("calendar feed not found")
+	}
+
+	tickets, err := s.ticketRepo.GetByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	type feedEntry struct {
+		event        entities.Event
+		bookingCodes []string
+		lastModified time.Time
+	}
+
+	entries := make(map[uint]*feedEntry)
+	for _, ticket := range tickets {
+		// Unpaid tickets are not in the calendar yet; the event may be deleted
+		if ticket.Status == constants.TicketStatusPending || ticket.Event.ID == 0 {
+			continue
+		}
+
+		entry, ok := entries[ticket.EventID]
+		if !ok {
+			entry = &feedEntry{event: ticket.Event, lastModified: ticket.Event.UpdatedAt}
+			entries[ticket.EventID] = entry
+		}
+		if ticket.Status != constants.TicketStatusCancelled {
+			entry.bookingCodes = append(entry.bookingCodes, ticket.BookingCode)
+		}
+		if ticket.UpdatedAt.After(entry.lastModified) {
+			entry.lastModified = ticket.UpdatedAt
+		}
+	}
+
+	events := make([]utils.ICalEvent, 0, len(entries))
+	for _, entry := range entries {
+		icalEvent := eventToICal(entry.event)
+		icalEvent.LastModified = entry.lastModified
+		if len(entry.bookingCodes) == 0 {
+			icalEvent.Cancelled = true
+		} else {
+			icalEvent.Description = strings.TrimSpace(icalEvent.Description + "\n\nBooking codes: " + strings.Join(entry.bookingCodes, ", "))
+		}
+		events = append(events, icalEvent)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	return utils.BuildICalendar("Malaka Ticket - "+user.Name, events, time.Now()), nil
+}
+
+// CreateFeedToken issues a new calendar feed token for the user, which
+// invalidates any previous feed URL.
+func (s *calendarService) CreateFeedToken(userID uint) (*dto.CalendarFeedResponse, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := utils.HashToken(token)
+	if err := s.userRepo.UpdateCalendarTokenHash(userID, &hash); err != nil {
+		return nil, err
+	}
+
+	return &dto.CalendarFeedResponse{
+		FeedURL: s.baseURL + "/public/calendar/" + token + ".ics",
+	}, nil
+}
+
+func (s *calendarService) RevokeFeedToken(userID uint) error {
+	return s.userRepo.UpdateCalendarTokenHash(userID, nil)
+}
+
+func eventToICal(event entities.Event) utils.ICalEvent {
+	icalEvent := utils.ICalEvent{
+		UID:          fmt.Sprintf("event-%d@malaka-ticket", event.ID),
+		Summary:      event.Title,
+		Description:  event.Description,
+		Location:     event.Location,
+		Start:        event.Date,
+		Cancelled:    event.Status == constants.EventStatusCancelled,
+		LastModified: event.UpdatedAt,
+	}
+	if event.EndDate.After(event.Date) {
+		icalEvent.End = event.EndDate
+	}
+	return icalEvent
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"case_study_api/utils"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CheckInService interface {
+	CheckIn(req dto.CheckInRequest, scannerID uint) (*dto.CheckInResponse, error)
+	VerifyQRPayload(req dto.VerifyTicketRequest) (*dto.VerifyTicketResponse, error)
+}
+
+type checkInService struct {
+	txManager   repositories.TxManager
+	ticketRepo  repositories.TicketRepository
+	eventRepo   repositories.EventRepository
+	checkInRepo repositories.TicketCheckInRepository
+}
+
+func NewCheckInService(txManager repositories.TxManager, ticketRepo repositories.TicketRepository, eventRepo repositories.EventRepository, checkInRepo repositories.TicketCheckInRepository) CheckInService {
+	return &checkInService{
+		txManager:   txManager,
+		ticketRepo:  ticketRepo,
+		eventRepo:   eventRepo,
+		checkInRepo: checkInRepo,
+	}
+}
+
+// CheckIn admits req.Count people on a ticket, or everyone still outstanding
+// when no count is given. The ticket is identified by its booking code or by
+// a signed QR payload. It becomes used once every admission on it has been
+// scanned.
+func (s *checkInService) CheckIn(req dto.CheckInRequest, scannerID uint) (*dto.CheckInResponse, error) {
+	var qrTicketID, qrEventID uint
+	if req.QRPayload != "" {
+		var err error
+		qrTicketID, qrEventID, err = utils.VerifyTicketPayload(req.QRPayload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var response dto.CheckInResponse
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		ticketRepo := s.ticketRepo.WithTx(tx)
+
+		// The row lock makes two gates scanning the same code at once
+		// admit the ticket only once
+		var ticket *entities.Ticket
+		var err error
+		if req.QRPayload != "" {
+			ticket, err = ticketRepo.GetByIDForUpdate(qrTicketID)
+		} else {
+			ticket, err = ticketRepo.GetByBookingCodeForUpdate(req.BookingCode)
+		}
+		if err != nil {
+			return errors.New("ticket not found")
+		}
+
+		if req.QRPayload != "" && ticket.EventID != qrEventID {
+			return utils.ErrInvalidTicketSignature
+		}
+		if req.EventID != 0 && ticket.EventID != req.EventID {
+			return errors.New("ticket is for a different event")
+		}
+
+		if ticket.Status == constants.TicketStatusUsed {
+			return errors.New("ticket has already been checked in")
+		}
+		if ticket.Status != constants.TicketStatusBooked {
+			return fmt.Errorf("ticket is %s and cannot be checked in", ticket.Status)
+		}
+
+		event, err := s.eventRepo.WithTx(tx).GetByID(ticket.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		now := time.Now()
+		if !isEventOpenForCheckIn(event, now) {
+			return errors.New("ticket is not valid for an ongoing or today's event")
+		}
+
+		remaining := ticket.Quantity - ticket.CheckedInCount
+		count := req.Count
+		if count == 0 {
+			count = remaining
+		}
+		if count > remaining {
+			return fmt.Errorf("only %d admissions left on this ticket", remaining)
+		}
+
+		ticket.CheckedInCount += count
+		ticket.CheckedInAt = &now
+		ticket.CheckedInBy = &scannerID
+		if ticket.CheckedInCount == ticket.Quantity {
+			ticket.Status = constants.TicketStatusUsed
+		}
+
+		if err := ticketRepo.Update(ticket); err != nil {
+			return err
+		}
+
+		checkIn := entities.TicketCheckIn{
+			TicketID:  ticket.ID,
+			Count:     count,
+			ScannedBy: scannerID,
+			ScannedAt: now,
+		}
+		if err := s.checkInRepo.WithTx(tx).Create(&checkIn); err != nil {
+			return err
+		}
+
+		response = dto.CheckInResponse{
+			TicketID:       ticket.ID,
+			BookingCode:    ticket.BookingCode,
+			EventID:        event.ID,
+			EventTitle:     event.Title,
+			Quantity:       ticket.Quantity,
+			Admitted:       count,
+			CheckedInCount: ticket.CheckedInCount,
+			Remaining:      ticket.Quantity - ticket.CheckedInCount,
+			Status:         ticket.Status,
+			CheckedInAt:    now.Format("2006-01-02T15:04:05Z"),
+			CheckedInBy:    scannerID,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// VerifyQRPayload validates a QR payload using only the signing secret, so
+// gates can reject forged codes before (or without) reaching the database.
+func (s *checkInService) VerifyQRPayload(req dto.VerifyTicketRequest) (*dto.VerifyTicketResponse, error) {
+	ticketID, eventID, err := utils.VerifyTicketPayload(req.QRPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.VerifyTicketResponse{
+		Valid:    true,
+		TicketID: ticketID,
+		EventID:  eventID,
+	}, nil
+}
+
+// isEventOpenForCheckIn reports whether gates may admit people to the event:
+// it is either marked ongoing or scheduled to run on the current day.
+func isEventOpenForCheckIn(event *entities.Event, now time.Time) bool {
+	if event.Status == constants.EventStatusCancelled || event.Status == constants.EventStatusCompleted {
+		return false
+	}
+	if event.Status == constants.EventStatusOngoing {
+		return true
+	}
+
+	endDate := event.EndDate
+	if endDate.IsZero() || endDate.Before(event.Date) {
+		endDate = event.Date
+	}
+
+	y, m, d := event.Date.Date()
+	startOfFirstDay := time.Date(y, m, d, 0, 0, 0, 0, event.Date.Location())
+	y, m, d = endDate.Date()
+	endOfLastDay := time.Date(y, m, d, 0, 0, 0, 0, endDate.Location()).AddDate(0, 0, 1)
+
+	return !now.Before(startOfFirstDay) && now.Before(endOfLastDay)
+}
@@ -0,0 +1,404 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EventCancellationService interface {
+	CancelEvent(eventID uint, req dto.CancelEventRequest, actorID uint) (*dto.EventCancellationResponse, error)
+	GetStatus(eventID uint) (*dto.EventCancellationResponse, error)
+	ResumeJobs() error
+}
+
+type eventCancellationService struct {
+	txManager        repositories.TxManager
+	eventRepo        repositories.EventRepository
+	historyRepo      repositories.EventStatusHistoryRepository
+	ticketRepo       repositories.TicketRepository
+	tierRepo         repositories.TicketTierRepository
+	orderRepo        repositories.OrderRepository
+	paymentRepo      repositories.PaymentRepository
+	refundRepo       repositories.RefundRepository
+	waitlistRepo     repositories.WaitlistRepository
+	sessionRepo      repositories.SessionRepository
+	seatRepo         repositories.SeatRepository
+	promoRepo        repositories.PromoCodeRepository
+	jobRepo          repositories.EventCancellationRepository
+	notificationRepo repositories.NotificationRepository
+	ticketService    TicketService
+	batchSize        int
+
+	mu      sync.Mutex
+	running map[uint]bool
+}
+
+func NewEventCancellationService(txManager repositories.TxManager, eventRepo repositories.EventRepository, historyRepo repositories.EventStatusHistoryRepository, ticketRepo repositories.TicketRepository, tierRepo repositories.TicketTierRepository, orderRepo repositories.OrderRepository, paymentRepo repositories.PaymentRepository, refundRepo repositories.RefundRepository, waitlistRepo repositories.WaitlistRepository, sessionRepo repositories.SessionRepository, seatRepo repositories.SeatRepository, promoRepo repositories.PromoCodeRepository, jobRepo repositories.EventCancellationRepository, notificationRepo repositories.NotificationRepository, ticketService TicketService, batchSize int) EventCancellationService {
+	return &eventCancellationService{
+		txManager:        txManager,
+		eventRepo:        eventRepo,
+		historyRepo:      historyRepo,
+		ticketRepo:       ticketRepo,
+		tierRepo:         tierRepo,
+		orderRepo:        orderRepo,
+		paymentRepo:      paymentRepo,
+		refundRepo:       refundRepo,
+		waitlistRepo:     waitlistRepo,
+		sessionRepo:      sessionRepo,
+		seatRepo:         seatRepo,
+		promoRepo:        promoRepo,
+		jobRepo:          jobRepo,
+		notificationRepo: notificationRepo,
+		ticketService:    ticketService,
+		batchSize:        batchSize,
+		running:          make(map[uint]bool),
+	}
+}
+
+// CancelEvent marks an event cancelled and starts a job that cancels its
+// tickets, refunds paid ones in full and notifies every ticket holder.
+// Cancelling an event that already has a job returns that job.
+func (s *eventCancellationService) CancelEvent(eventID uint, req dto.CancelEventRequest, actorID uint) (*dto.EventCancellationResponse, error) {
+	var job *entities.EventCancellationJob
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		eventRepo := s.eventRepo.WithTx(tx)
+		jobRepo := s.jobRepo.WithTx(tx)
+
+		event, err := eventRepo.GetByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		if existing, err := jobRepo.GetByEventID(event.ID); err == nil {
+			job = existing
+			return nil
+		}
+
+		// Events cancelled before jobs existed still need their tickets handled
+		if event.Status != constants.EventStatusCancelled {
+			if err := transitionEvent(eventRepo, s.historyRepo.WithTx(tx), event, constants.EventStatusCancelled, &actorID, req.Reason, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		total, err := s.ticketRepo.WithTx(tx).CountActiveByEventID(event.ID)
+		if err != nil {
+			return err
+		}
+
+		job = &entities.EventCancellationJob{
+			EventID:      event.ID,
+			RequestedBy:  actorID,
+			Reason:       req.Reason,
+			Status:       constants.JobStatusPending,
+			TotalTickets: total,
+		}
+		return jobRepo.Create(job)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != constants.JobStatusCompleted {
+		go func(jobID uint) {
+			if err := s.runJob(jobID); err != nil {
+				log.Printf("Cancellation job %d stopped: %v", jobID, err)
+			}
+		}(job.ID)
+	}
+
+	return s.entityToResponse(*job), nil
+}
+
+func (s *eventCancellationService) GetStatus(eventID uint) (*dto.EventCancellationResponse, error) {
+	job, err := s.jobRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, errors.New("event has not been cancelled")
+	}
+
+	return s.entityToResponse(*job), nil
+}
+
+// ResumeJobs carries on with every job that has not completed yet, for
+// example after a restart.
+func (s *eventCancellationService) ResumeJobs() error {
+	ids, err := s.jobRepo.GetUnfinishedIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := s.runJob(id); err != nil {
+			log.Printf("Cancellation job %d stopped: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// runJob processes a job batch by batch until every ticket is handled. Each
+// batch commits together with the job cursor, so a crash loses at most the
+// batch in flight. Refunds are paid out once their batch has committed.
+func (s *eventCancellationService) runJob(jobID uint) error {
+	// Only one runner per job in this process; the row lock guards the rest
+	s.mu.Lock()
+	if s.running[jobID] {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running[jobID] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, jobID)
+		s.mu.Unlock()
+	}()
+
+	for {
+		done, refundIDs, eventID, err := s.runBatch(jobID)
+		if err != nil {
+			s.recordError(jobID, err)
+			return err
+		}
+
+		s.processRefunds(refundIDs)
+
+		if done {
+			// Pick up refunds a previous run committed but never paid out
+			pending, err := s.refundRepo.GetPendingIDsByEventID(eventID)
+			if err != nil {
+				return err
+			}
+			s.processRefunds(pending)
+			return nil
+		}
+	}
+}
+
+func (s *eventCancellationService) runBatch(jobID uint) (bool, []uint, uint, error) {
+	var done bool
+	var refundIDs []uint
+	var eventID uint
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		jobRepo := s.jobRepo.WithTx(tx)
+
+		job, err := jobRepo.GetByIDForUpdate(jobID)
+		if err != nil {
+			return err
+		}
+		eventID = job.EventID
+
+		if job.Status == constants.JobStatusCompleted {
+			done = true
+			return nil
+		}
+
+		now := time.Now()
+		if job.Status == constants.JobStatusPending {
+			job.Status = constants.JobStatusRunning
+			job.StartedAt = &now
+		}
+
+		ids, err := s.ticketRepo.WithTx(tx).GetActiveIDsByEventID(job.EventID, job.LastTicketID, s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			if err := s.waitlistRepo.WithTx(tx).CancelWaitingByEventID(job.EventID); err != nil {
+				return err
+			}
+
+			job.Status = constants.JobStatusCompleted
+			job.CompletedAt = &now
+			job.LastError = ""
+			done = true
+			log.Printf("Cancellation of event %d completed, %d tickets cancelled", job.EventID, job.ProcessedTickets)
+			return jobRepo.Update(job)
+		}
+
+		event, err := s.eventRepo.WithTx(tx).GetByID(job.EventID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			refund, err := s.cancelTicket(tx, id, event, job.Reason, now)
+			if err != nil {
+				return err
+			}
+			if refund != nil {
+				job.RefundedAmount += refund.Amount
+				if refund.Status == constants.RefundStatusPending {
+					refundIDs = append(refundIDs, refund.ID)
+				}
+			}
+		}
+
+		job.LastTicketID = ids[len(ids)-1]
+		job.ProcessedTickets += int64(len(ids))
+		job.LastError = ""
+		return jobRepo.Update(job)
+	})
+
+	return done, refundIDs, eventID, err
+}
+
+// cancelTicket cancels one ticket of a cancelled event, releasing its
+// capacity, seats, session places and promo redemption as an individual
+// cancellation does. Paid tickets get a full refund, unpaid ones have their
+// order expired. The ticket holder is notified either way.
+//
+// An ongoing event can be cancelled too. A ticket that was only partly
+// checked in is still booked and is refunded in full on purpose: the
+// organizer called the event off, so holders are not charged for the part
+// they attended. Fully used tickets are left alone.
+func (s *eventCancellationService) cancelTicket(tx *gorm.DB, ticketID uint, event *entities.Event, reason string, now time.Time) (*entities.Refund, error) {
+	ticketRepo := s.ticketRepo.WithTx(tx)
+
+	ticket, err := ticketRepo.GetByIDForUpdate(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ticket may have changed since the batch was read
+	previousStatus := ticket.Status
+	if previousStatus != constants.TicketStatusPending && previousStatus != constants.TicketStatusBooked {
+		return nil, nil
+	}
+
+	ticket.Status = constants.TicketStatusCancelled
+	ticket.CancelledAt = &now
+	ticket.CancelReason = "event cancelled: " + reason
+	if err := ticketRepo.Update(ticket); err != nil {
+		return nil, err
+	}
+
+	if ticket.TierID != nil {
+		if err := s.tierRepo.WithTx(tx).DecrementSold(*ticket.TierID, ticket.Quantity); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.eventRepo.WithTx(tx).DecrementSoldTickets(ticket.EventID, ticket.Quantity); err != nil {
+		return nil, err
+	}
+	if err := s.seatRepo.WithTx(tx).ReleaseByTicketID(ticket.ID); err != nil {
+		return nil, err
+	}
+	if err := releasePromoRedemption(s.promoRepo.WithTx(tx), ticket); err != nil {
+		return nil, err
+	}
+	if err := releaseSessionPlaces(s.sessionRepo.WithTx(tx), ticketRepo, ticket.EventID, ticket.UserID); err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.WithTx(tx).GetByTicketID(ticket.ID)
+	if err != nil {
+		return nil, fmt.Errorf("order for ticket %d not found", ticket.ID)
+	}
+
+	var refund *entities.Refund
+	body := fmt.Sprintf("%s on %s has been cancelled: %s. Your booking %s is cancelled.",
+		event.Title, event.Date.Format("2006-01-02"), reason, ticket.BookingCode)
+
+	if previousStatus == constants.TicketStatusPending {
+		order.Status = constants.PaymentStatusExpired
+		if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+			return nil, err
+		}
+		if err := s.paymentRepo.WithTx(tx).UpdateStatusByOrderID(order.ID, constants.PaymentStatusPending, constants.PaymentStatusExpired, nil); err != nil {
+			return nil, err
+		}
+	} else {
+		refund = &entities.Refund{
+			TicketID:   ticket.ID,
+			OrderID:    order.ID,
+			UserID:     ticket.UserID,
+			Amount:     order.Amount,
+			Percentage: 100,
+			Status:     constants.RefundStatusPending,
+			Reason:     ticket.CancelReason,
+		}
+		if refund.Amount == 0 {
+			refund.Status = constants.RefundStatusDeclined
+		}
+		if err := s.refundRepo.WithTx(tx).Create(refund); err != nil {
+			return nil, err
+		}
+		body += fmt.Sprintf(" A full refund of %.2f is on its way.", refund.Amount)
+	}
+
+	notification := entities.Notification{
+		UserID:   ticket.UserID,
+		Type:     constants.NotificationTypeEventCancelled,
+		Subject:  "Event cancelled: " + event.Title,
+		Body:     body,
+		Status:   constants.NotificationStatusQueued,
+		EventID:  &event.ID,
+		TicketID: &ticket.ID,
+	}
+	if err := s.notificationRepo.WithTx(tx).Create(&notification); err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (s *eventCancellationService) processRefunds(ids []uint) {
+	for _, id := range ids {
+		if err := s.ticketService.ProcessRefund(id); err != nil {
+			log.Printf("Failed to process refund %d: %v", id, err)
+		}
+	}
+}
+
+func (s *eventCancellationService) recordError(jobID uint, jobErr error) {
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		jobRepo := s.jobRepo.WithTx(tx)
+
+		job, err := jobRepo.GetByIDForUpdate(jobID)
+		if err != nil {
+			return err
+		}
+		job.LastError = jobErr.Error()
+		return jobRepo.Update(job)
+	})
+	if err != nil {
+		log.Printf("Failed to record error for cancellation job %d: %v", jobID, err)
+	}
+}
+
+func (s *eventCancellationService) entityToResponse(job entities.EventCancellationJob) *dto.EventCancellationResponse {
+	response := &dto.EventCancellationResponse{
+		EventID:          job.EventID,
+		Status:           job.Status,
+		Reason:           job.Reason,
+		TotalTickets:     job.TotalTickets,
+		ProcessedTickets: job.ProcessedTickets,
+		RefundedAmount:   job.RefundedAmount,
+		LastError:        job.LastError,
+		RequestedAt:      job.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if job.StartedAt != nil {
+		startedAt := job.StartedAt.Format("2006-01-02T15:04:05Z")
+		response.StartedAt = &startedAt
+	}
+	if job.CompletedAt != nil {
+		completedAt := job.CompletedAt.Format("2006-01-02T15:04:05Z")
+		response.CompletedAt = &completedAt
+	}
+
+	return response
+}
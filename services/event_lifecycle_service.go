@@ -0,0 +1,170 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/repositories"
+	"case_study_api/utils"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EventLifecycleService interface {
+	RunTransitions() (*dto.LifecycleRunResponse, error)
+	GetStats() dto.LifecycleStatsResponse
+}
+
+type eventLifecycleService struct {
+	txManager   repositories.TxManager
+	eventRepo   repositories.EventRepository
+	ticketRepo  repositories.TicketRepository
+	historyRepo repositories.EventStatusHistoryRepository
+	clock       utils.Clock
+
+	mu    sync.Mutex
+	stats dto.LifecycleStatsResponse
+}
+
+func NewEventLifecycleService(txManager repositories.TxManager, eventRepo repositories.EventRepository, ticketRepo repositories.TicketRepository, historyRepo repositories.EventStatusHistoryRepository, clock utils.Clock) EventLifecycleService {
+	return &eventLifecycleService{
+		txManager:   txManager,
+		eventRepo:   eventRepo,
+		ticketRepo:  ticketRepo,
+		historyRepo: historyRepo,
+		clock:       clock,
+	}
+}
+
+// RunTransitions moves upcoming events that have started to ongoing and
+// ongoing events that have ended to completed. Completing an event marks its
+// unscanned booked tickets as no-shows. An event whose whole run lies in the
+// past goes through both steps in a single run.
+func (s *eventLifecycleService) RunTransitions() (*dto.LifecycleRunResponse, error) {
+	now := s.clock.Now()
+	run := dto.LifecycleRunResponse{RanAt: now.Format("2006-01-02T15:04:05Z")}
+
+	err := s.startEvents(now, &run)
+	if err == nil {
+		err = s.completeEvents(now, &run)
+	}
+
+	s.record(now, run, err)
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (s *eventLifecycleService) GetStats() dto.LifecycleStatsResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	if stats.LastRun != nil {
+		lastRun := *stats.LastRun
+		stats.LastRun = &lastRun
+	}
+	return stats
+}
+
+func (s *eventLifecycleService) startEvents(now time.Time, run *dto.LifecycleRunResponse) error {
+	ids, err := s.eventRepo.GetIDsDueToStart(now)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+			eventRepo := s.eventRepo.WithTx(tx)
+
+			// Re-check under the lock, an admin may have changed the event
+			event, err := eventRepo.GetByIDForUpdate(id)
+			if err != nil {
+				return err
+			}
+			if event.Status != constants.EventStatusUpcoming || event.Date.After(now) {
+				return nil
+			}
+
+			if err := transitionEvent(eventRepo, s.historyRepo.WithTx(tx), event, constants.EventStatusOngoing, nil, "scheduled start", now); err != nil {
+				return err
+			}
+
+			log.Printf("Event %d (%s) moved from %s to %s", event.ID, event.Title, constants.EventStatusUpcoming, constants.EventStatusOngoing)
+			run.EventsStarted++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *eventLifecycleService) completeEvents(now time.Time, run *dto.LifecycleRunResponse) error {
+	ids, err := s.eventRepo.GetIDsDueToComplete(now)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+			eventRepo := s.eventRepo.WithTx(tx)
+
+			event, err := eventRepo.GetByIDForUpdate(id)
+			if err != nil {
+				return err
+			}
+
+			endDate := event.EndDate
+			if endDate.Before(event.Date) {
+				endDate = event.Date
+			}
+			if event.Status != constants.EventStatusOngoing || endDate.After(now) {
+				return nil
+			}
+
+			if err := transitionEvent(eventRepo, s.historyRepo.WithTx(tx), event, constants.EventStatusCompleted, nil, "scheduled end", now); err != nil {
+				return err
+			}
+
+			noShows, err := s.ticketRepo.WithTx(tx).MarkNoShows(event.ID)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Event %d (%s) moved from %s to %s, %d tickets marked as no-show", event.ID, event.Title, constants.EventStatusOngoing, constants.EventStatusCompleted, noShows)
+			run.EventsCompleted++
+			run.NoShowTickets += noShows
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// record folds a run into the running totals. Transitions made before an
+// error are counted as well, they were committed.
+func (s *eventLifecycleService) record(now time.Time, run dto.LifecycleRunResponse, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Runs++
+	s.stats.TotalEventsStarted += int64(run.EventsStarted)
+	s.stats.TotalEventsCompleted += int64(run.EventsCompleted)
+	s.stats.TotalNoShowTickets += run.NoShowTickets
+	s.stats.LastRun = &run
+
+	if err != nil {
+		errorAt := now.Format("2006-01-02T15:04:05Z")
+		s.stats.LastError = err.Error()
+		s.stats.LastErrorAt = &errorAt
+	}
+}
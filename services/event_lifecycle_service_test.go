@@ -0,0 +1,220 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// fakeTxManager runs the callback directly; the fake repositories ignore
+// the transaction handle.
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithTransaction(fn func(tx *gorm.DB) error) error {
+	return fn(nil)
+}
+
+// The fakes embed the repository interfaces so only the methods the
+// lifecycle service calls need an implementation.
+type fakeEventRepo struct {
+	repositories.EventRepository
+	events map[uint]*entities.Event
+}
+
+func (r *fakeEventRepo) WithTx(tx *gorm.DB) repositories.EventRepository {
+	return r
+}
+
+func (r *fakeEventRepo) GetByIDForUpdate(id uint) (*entities.Event, error) {
+	event, ok := r.events[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *event
+	return &copied, nil
+}
+
+func (r *fakeEventRepo) Update(event *entities.Event) error {
+	if _, ok := r.events[event.ID]; !ok {
+		return errors.New("event not found")
+	}
+	copied := *event
+	r.events[event.ID] = &copied
+	return nil
+}
+
+func (r *fakeEventRepo) GetIDsDueToStart(now time.Time) ([]uint, error) {
+	return r.ids(func(event *entities.Event) bool {
+		return event.Status == constants.EventStatusUpcoming && !event.Date.After(now)
+	}), nil
+}
+
+func (r *fakeEventRepo) GetIDsDueToComplete(now time.Time) ([]uint, error) {
+	return r.ids(func(event *entities.Event) bool {
+		endDate := event.EndDate
+		if endDate.Before(event.Date) {
+			endDate = event.Date
+		}
+		return event.Status == constants.EventStatusOngoing && !endDate.After(now)
+	}), nil
+}
+
+func (r *fakeEventRepo) ids(match func(event *entities.Event) bool) []uint {
+	var ids []uint
+	for id, event := range r.events {
+		if match(event) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+type fakeTicketRepo struct {
+	repositories.TicketRepository
+	tickets []*entities.Ticket
+}
+
+func (r *fakeTicketRepo) WithTx(tx *gorm.DB) repositories.TicketRepository {
+	return r
+}
+
+func (r *fakeTicketRepo) MarkNoShows(eventID uint) (int64, error) {
+	var marked int64
+	for _, ticket := range r.tickets {
+		if ticket.EventID == eventID && ticket.Status == constants.TicketStatusBooked && ticket.CheckedInCount == 0 {
+			ticket.Status = constants.TicketStatusNoShow
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+type fakeHistoryRepo struct {
+	repositories.EventStatusHistoryRepository
+	history []entities.EventStatusHistory
+}
+
+func (r *fakeHistoryRepo) WithTx(tx *gorm.DB) repositories.EventStatusHistoryRepository {
+	return r
+}
+
+func (r *fakeHistoryRepo) Create(history *entities.EventStatusHistory) error {
+	r.history = append(r.history, *history)
+	return nil
+}
+
+func TestRunTransitions(t *testing.T) {
+	base := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+
+	scheduled := &entities.Event{Status: constants.EventStatusUpcoming, Date: base.Add(time.Hour), EndDate: base.Add(3 * time.Hour)}
+	scheduled.ID = 1
+	// Its whole run is already over, one run takes it through both steps
+	missed := &entities.Event{Status: constants.EventStatusUpcoming, Date: base.Add(-3 * time.Hour), EndDate: base.Add(-2 * time.Hour)}
+	missed.ID = 2
+
+	eventRepo := &fakeEventRepo{events: map[uint]*entities.Event{1: scheduled, 2: missed}}
+	ticketRepo := &fakeTicketRepo{tickets: []*entities.Ticket{
+		{EventID: 1, Status: constants.TicketStatusBooked},
+		{EventID: 1, Status: constants.TicketStatusBooked},
+		{EventID: 1, Status: constants.TicketStatusUsed, CheckedInCount: 1},
+		{EventID: 1, Status: constants.TicketStatusCancelled},
+		{EventID: 2, Status: constants.TicketStatusBooked},
+	}}
+	historyRepo := &fakeHistoryRepo{}
+	clock := &fakeClock{now: base}
+
+	service := NewEventLifecycleService(fakeTxManager{}, eventRepo, ticketRepo, historyRepo, clock)
+
+	run, err := service.RunTransitions()
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if run.EventsStarted != 1 || run.EventsCompleted != 1 || run.NoShowTickets != 1 {
+		t.Fatalf("first run = %+v, want 1 started, 1 completed, 1 no-show", run)
+	}
+	if got := eventRepo.events[2].Status; got != constants.EventStatusCompleted {
+		t.Errorf("missed event is %s, want %s", got, constants.EventStatusCompleted)
+	}
+	if got := eventRepo.events[1].Status; got != constants.EventStatusUpcoming {
+		t.Errorf("scheduled event is %s before its start, want %s", got, constants.EventStatusUpcoming)
+	}
+
+	clock.now = base.Add(2 * time.Hour)
+	run, err = service.RunTransitions()
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if run.EventsStarted != 1 || run.EventsCompleted != 0 || run.NoShowTickets != 0 {
+		t.Fatalf("second run = %+v, want only 1 started", run)
+	}
+	if got := eventRepo.events[1].Status; got != constants.EventStatusOngoing {
+		t.Errorf("scheduled event is %s during its run, want %s", got, constants.EventStatusOngoing)
+	}
+	for i, ticket := range ticketRepo.tickets[:2] {
+		if ticket.Status != constants.TicketStatusBooked {
+			t.Errorf("ticket %d is %s while the event runs, want %s", i, ticket.Status, constants.TicketStatusBooked)
+		}
+	}
+
+	clock.now = base.Add(4 * time.Hour)
+	run, err = service.RunTransitions()
+	if err != nil {
+		t.Fatalf("third run: %v", err)
+	}
+	if run.EventsStarted != 0 || run.EventsCompleted != 1 || run.NoShowTickets != 2 {
+		t.Fatalf("third run = %+v, want 1 completed with 2 no-shows", run)
+	}
+	if got := eventRepo.events[1].Status; got != constants.EventStatusCompleted {
+		t.Errorf("scheduled event is %s after its end, want %s", got, constants.EventStatusCompleted)
+	}
+
+	want := []string{
+		constants.TicketStatusNoShow,
+		constants.TicketStatusNoShow,
+		constants.TicketStatusUsed,
+		constants.TicketStatusCancelled,
+		constants.TicketStatusNoShow,
+	}
+	for i, ticket := range ticketRepo.tickets {
+		if ticket.Status != want[i] {
+			t.Errorf("ticket %d is %s, want %s", i, ticket.Status, want[i])
+		}
+	}
+
+	// upcoming -> ongoing -> completed for both events, in order
+	if len(historyRepo.history) != 4 {
+		t.Fatalf("recorded %d status changes, want 4", len(historyRepo.history))
+	}
+	for _, history := range historyRepo.history {
+		if history.ActorID != nil {
+			t.Errorf("status change of event %d has actor %d, want a system change", history.EventID, *history.ActorID)
+		}
+	}
+	last := historyRepo.history[3]
+	if last.EventID != 1 || last.FromStatus != constants.EventStatusOngoing || last.ToStatus != constants.EventStatusCompleted {
+		t.Errorf("last status change = %+v, want event 1 from ongoing to completed", last)
+	}
+
+	stats := service.GetStats()
+	if stats.Runs != 3 || stats.TotalEventsStarted != 2 || stats.TotalEventsCompleted != 2 || stats.TotalNoShowTickets != 3 {
+		t.Errorf("stats = %+v, want 3 runs, 2 started, 2 completed, 3 no-shows", stats)
+	}
+	if stats.LastError != "" {
+		t.Errorf("stats report error %q after clean runs", stats.LastError)
+	}
+}
@@ -0,0 +1,469 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+	"gorm.io/gorm"
+)
+
+// A series generates at most maxSeriesOccurrences events within
+// seriesHorizon of its start, so rules without COUNT or UNTIL stay finite.
+const (
+	maxSeriesOccurrences = 100
+	seriesHorizon        = 365 * 24 * time.Hour
+)
+
+type EventSeriesService interface {
+	GetAll() ([]dto.EventSeriesResponse, error)
+	GetByID(id uint) (*dto.EventSeriesResponse, error)
+	Create(req dto.CreateEventSeriesRequest, createdBy uint) (*dto.EventSeriesResponse, error)
+	UpdateOccurrence(seriesID uint, eventID uint, req dto.UpdateSeriesOccurrenceRequest) (*dto.EventSeriesResponse, error)
+	AddException(seriesID uint, req dto.EventSeriesExceptionRequest) (*dto.EventSeriesResponse, error)
+}
+
+type eventSeriesService struct {
+	txManager  repositories.TxManager
+	seriesRepo repositories.EventSeriesRepository
+	eventRepo  repositories.EventRepository
+	venueRepo  repositories.VenueRepository
+}
+
+func NewEventSeriesService(txManager repositories.TxManager, seriesRepo repositories.EventSeriesRepository, eventRepo repositories.EventRepository, venueRepo repositories.VenueRepository) EventSeriesService {
+	return &eventSeriesService{
+		txManager:  txManager,
+		seriesRepo: seriesRepo,
+		eventRepo:  eventRepo,
+		venueRepo:  venueRepo,
+	}
+}
+
+func (s *eventSeriesService) GetAll() ([]dto.EventSeriesResponse, error) {
+	series, err := s.seriesRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	seriesResponses := make([]dto.EventSeriesResponse, 0, len(series))
+	for _, entry := range series {
+		seriesResponses = append(seriesResponses, s.entityToResponse(entry))
+	}
+
+	return seriesResponses, nil
+}
+
+func (s *eventSeriesService) GetByID(id uint) (*dto.EventSeriesResponse, error) {
+	series, err := s.seriesRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("series not found")
+	}
+
+	response := s.entityToResponse(*series)
+	return &response, nil
+}
+
+// Create saves a series and generates its occurrences in one transaction,
+// so a single occurrence clashing at the venue rejects the whole series.
+func (s *eventSeriesService) Create(req dto.CreateEventSeriesRequest, createdBy uint) (*dto.EventSeriesResponse, error) {
+	startsAt, err := time.Parse("2006-01-02T15:04:05Z", req.StartsAt)
+	if err != nil {
+		return nil, errors.New("invalid starts_at format")
+	}
+	if !startsAt.After(time.Now()) {
+		return nil, errors.New("starts_at must be in the future")
+	}
+
+	ruleString := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(req.RRule)), "RRULE:")
+	rule, err := parseSeriesRule(ruleString, startsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.VenueID == nil {
+		if req.HallID != nil {
+			return nil, errors.New("hall requires a venue")
+		}
+		if req.Location == "" {
+			return nil, errors.New("location is required when no venue is set")
+		}
+	}
+
+	series := entities.EventSeries{
+		Title:           req.Title,
+		Description:     req.Description,
+		Location:        req.Location,
+		Category:        req.Category,
+		RRule:           ruleString,
+		StartsAt:        startsAt,
+		DurationMinutes: req.DurationMinutes,
+		Capacity:        req.Capacity,
+		Price:           req.Price,
+		VenueID:         req.VenueID,
+		HallID:          req.HallID,
+		CreatedBy:       createdBy,
+	}
+
+	skip := make(map[string]bool, len(req.Exceptions))
+	for _, exceptionReq := range req.Exceptions {
+		date, err := time.Parse("2006-01-02", exceptionReq.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exception date %q", exceptionReq.Date)
+		}
+		if skip[exceptionReq.Date] {
+			return nil, fmt.Errorf("duplicate exception date %q", exceptionReq.Date)
+		}
+		skip[exceptionReq.Date] = true
+
+		series.Exceptions = append(series.Exceptions, entities.EventSeriesException{
+			Date:   date,
+			Reason: exceptionReq.Reason,
+		})
+	}
+
+	dates := seriesOccurrences(rule, skip)
+	if len(dates) == 0 {
+		return nil, errors.New("rrule produces no occurrences")
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		eventRepo := s.eventRepo.WithTx(tx)
+		venueRepo := s.venueRepo.WithTx(tx)
+
+		if series.VenueID != nil && series.Location == "" {
+			venue, err := venueRepo.GetByID(*series.VenueID)
+			if err != nil {
+				return errors.New("venue not found")
+			}
+			series.Location = venue.Name + ", " + venue.City
+		}
+
+		if err := s.seriesRepo.WithTx(tx).Create(&series); err != nil {
+			return err
+		}
+
+		for _, date := range dates {
+			event := newOccurrence(series, date)
+			if event.VenueID != nil {
+				if _, err := checkEventVenue(venueRepo, eventRepo, &event); err != nil {
+					return fmt.Errorf("occurrence on %s: %v", date.Format("2006-01-02"), err)
+				}
+			}
+			if err := eventRepo.Create(&event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(series.ID)
+}
+
+// UpdateOccurrence edits an upcoming occurrence. With the future scope the
+// same changes apply to every later upcoming occurrence and to the series,
+// while occurrences before it keep their details.
+func (s *eventSeriesService) UpdateOccurrence(seriesID uint, eventID uint, req dto.UpdateSeriesOccurrenceRequest) (*dto.EventSeriesResponse, error) {
+	var startTime *time.Time
+	if req.StartTime != "" {
+		parsed, err := time.Parse("15:04", req.StartTime)
+		if err != nil {
+			return nil, errors.New("invalid start_time format, use HH:MM")
+		}
+		startTime = &parsed
+	}
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		seriesRepo := s.seriesRepo.WithTx(tx)
+		eventRepo := s.eventRepo.WithTx(tx)
+
+		series, err := seriesRepo.GetByIDForUpdate(seriesID)
+		if err != nil {
+			return errors.New("series not found")
+		}
+
+		event, err := eventRepo.GetByIDForUpdate(eventID)
+		if err != nil || event.SeriesID == nil || *event.SeriesID != series.ID {
+			return errors.New("occurrence not found in this series")
+		}
+		if event.Status != constants.EventStatusUpcoming || !time.Now().Before(event.Date) {
+			return errors.New("only upcoming occurrences can be edited")
+		}
+
+		events := []entities.Event{*event}
+		if req.Scope == constants.SeriesScopeFuture {
+			events, err = seriesRepo.GetUpcomingEventsForUpdate(series.ID, event.Date)
+			if err != nil {
+				return err
+			}
+
+			applySeriesTemplate(series, req, startTime)
+			if err := seriesRepo.Update(series); err != nil {
+				return err
+			}
+		}
+
+		for i := range events {
+			occurrence := &events[i]
+			if err := applyOccurrenceUpdate(occurrence, req, startTime); err != nil {
+				return err
+			}
+
+			if occurrence.VenueID != nil {
+				if _, err := checkEventVenue(s.venueRepo.WithTx(tx), eventRepo, occurrence); err != nil {
+					return fmt.Errorf("occurrence on %s: %v", occurrence.Date.Format("2006-01-02"), err)
+				}
+			}
+
+			if err := eventRepo.Update(occurrence); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(seriesID)
+}
+
+// AddException skips a date of the series. An occurrence already generated
+// for that date is removed, unless it has sold tickets; those have to be
+// cancelled through the event instead so buyers are refunded.
+func (s *eventSeriesService) AddException(seriesID uint, req dto.EventSeriesExceptionRequest) (*dto.EventSeriesResponse, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, errors.New("invalid date format, use YYYY-MM-DD")
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		seriesRepo := s.seriesRepo.WithTx(tx)
+
+		series, err := seriesRepo.GetByIDForUpdate(seriesID)
+		if err != nil {
+			return errors.New("series not found")
+		}
+
+		exists, err := seriesRepo.HasException(series.ID, date)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New("series already skips this date")
+		}
+
+		event, err := seriesRepo.GetEventOnDate(series.ID, date)
+		if err != nil {
+			return err
+		}
+		if event != nil {
+			if event.SoldTickets > 0 {
+				return fmt.Errorf("the occurrence on %s has sold tickets, cancel the event instead", req.Date)
+			}
+			if err := s.eventRepo.WithTx(tx).Delete(event); err != nil {
+				return err
+			}
+		}
+
+		return seriesRepo.CreateException(&entities.EventSeriesException{
+			SeriesID: series.ID,
+			Date:     date,
+			Reason:   req.Reason,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(seriesID)
+}
+
+// parseSeriesRule builds the recurrence of a series from a single RRULE
+// line. DTSTART always comes from the series start.
+func parseSeriesRule(value string, startsAt time.Time) (*rrule.RRule, error) {
+	if strings.ContainsAny(value, "\r\n") {
+		return nil, errors.New("rrule must be a single RRULE line")
+	}
+
+	option, err := rrule.StrToROption(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %v", err)
+	}
+	if option.Freq > rrule.DAILY {
+		return nil, errors.New("rrule frequency must be DAILY, WEEKLY, MONTHLY or YEARLY")
+	}
+	option.Dtstart = startsAt
+
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rrule: %v", err)
+	}
+	return rule, nil
+}
+
+// seriesOccurrences lists the start times of a series, leaving out the
+// dates in skip.
+func seriesOccurrences(rule *rrule.RRule, skip map[string]bool) []time.Time {
+	horizon := rule.GetDTStart().Add(seriesHorizon)
+	next := rule.Iterator()
+
+	var dates []time.Time
+	for len(dates) < maxSeriesOccurrences {
+		date, ok := next()
+		if !ok || date.After(horizon) {
+			break
+		}
+		if skip[date.Format("2006-01-02")] {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+func newOccurrence(series entities.EventSeries, date time.Time) entities.Event {
+	seriesID := series.ID
+	return entities.Event{
+		Title:       occurrenceTitle(series.Title, date),
+		Description: series.Description,
+		Location:    series.Location,
+		Category:    series.Category,
+		Status:      constants.EventStatusUpcoming,
+		Date:        date,
+		EndDate:     date.Add(time.Duration(series.DurationMinutes) * time.Minute),
+		Capacity:    series.Capacity,
+		Price:       series.Price,
+		CreatedBy:   series.CreatedBy,
+		IsActive:    true,
+		VenueID:     series.VenueID,
+		HallID:      series.HallID,
+		SeriesID:    &seriesID,
+	}
+}
+
+// occurrenceTitle keeps occurrence titles unique by adding their date.
+func occurrenceTitle(title string, date time.Time) string {
+	return fmt.Sprintf("%s (%s)", title, date.Format("2006-01-02"))
+}
+
+// applySeriesTemplate copies the provided fields of req onto the series so
+// they describe its future occurrences.
+func applySeriesTemplate(series *entities.EventSeries, req dto.UpdateSeriesOccurrenceRequest, startTime *time.Time) {
+	if req.Title != "" {
+		series.Title = req.Title
+	}
+	if req.Description != "" {
+		series.Description = req.Description
+	}
+	if req.Location != "" {
+		series.Location = req.Location
+	}
+	if req.Category != "" {
+		series.Category = req.Category
+	}
+	if req.Capacity > 0 {
+		series.Capacity = req.Capacity
+	}
+	if req.Price != nil {
+		series.Price = *req.Price
+	}
+	if req.DurationMinutes > 0 {
+		series.DurationMinutes = req.DurationMinutes
+	}
+	if startTime != nil {
+		series.StartsAt = atTimeOfDay(series.StartsAt, *startTime)
+	}
+}
+
+// applyOccurrenceUpdate copies the provided fields of req onto an
+// occurrence. Occurrences edited with the future scope are retitled after
+// the series, so they keep their date suffix.
+func applyOccurrenceUpdate(event *entities.Event, req dto.UpdateSeriesOccurrenceRequest, startTime *time.Time) error {
+	if req.Title != "" {
+		if req.Scope == constants.SeriesScopeFuture {
+			event.Title = occurrenceTitle(req.Title, event.Date)
+		} else {
+			event.Title = req.Title
+		}
+	}
+	if req.Description != "" {
+		event.Description = req.Description
+	}
+	if req.Location != "" {
+		event.Location = req.Location
+	}
+	if req.Category != "" {
+		event.Category = req.Category
+	}
+	if req.Capacity > 0 {
+		if req.Capacity < event.SoldTickets {
+			return fmt.Errorf("capacity of %q cannot be lower than its %d sold tickets", event.Title, event.SoldTickets)
+		}
+		event.Capacity = req.Capacity
+	}
+	if req.Price != nil {
+		event.Price = *req.Price
+	}
+
+	duration := event.EndDate.Sub(event.Date)
+	if duration < 0 {
+		duration = 0
+	}
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+	if startTime != nil {
+		event.Date = atTimeOfDay(event.Date, *startTime)
+		if !event.Date.After(time.Now()) {
+			return fmt.Errorf("%q cannot be moved into the past", event.Title)
+		}
+	}
+	event.EndDate = event.Date.Add(duration)
+
+	return nil
+}
+
+// atTimeOfDay returns date moved to the hour and minute of clock.
+func atTimeOfDay(date time.Time, clock time.Time) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, clock.Hour(), clock.Minute(), 0, 0, date.Location())
+}
+
+func (s *eventSeriesService) entityToResponse(series entities.EventSeries) dto.EventSeriesResponse {
+	response := dto.EventSeriesResponse{
+		ID:              series.ID,
+		Title:           series.Title,
+		Description:     series.Description,
+		Location:        series.Location,
+		Category:        series.Category,
+		RRule:           series.RRule,
+		StartsAt:        series.StartsAt.Format("2006-01-02T15:04:05Z"),
+		DurationMinutes: series.DurationMinutes,
+		Capacity:        series.Capacity,
+		Price:           series.Price,
+		VenueID:         series.VenueID,
+		HallID:          series.HallID,
+		CreatedBy:       series.CreatedBy,
+	}
+
+	for _, exception := range series.Exceptions {
+		response.Exceptions = append(response.Exceptions, dto.EventSeriesExceptionResponse{
+			Date:   exception.Date.Format("2006-01-02"),
+			Reason: exception.Reason,
+		})
+	}
+	for _, event := range series.Events {
+		response.Occurrences = append(response.Occurrences, eventToResponse(event))
+	}
+
+	return response
+}
@@ -1,30 +1,52 @@
 package services
 
 import (
+	"case_study_api/constants"
 	"case_study_api/dto"
 	"case_study_api/entities"
 	"case_study_api/repositories"
 	"case_study_api/utils"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// Cancelling an event also cancels and refunds its tickets, which only the
+// cancel endpoint does.
+var errCancelThroughEndpoint = errors.New("use POST /api/events/:id/cancel to cancel an event")
+
 type EventService interface {
 	GetAll() ([]dto.EventResponse, error)
-	GetAllPaginated(pagination utils.PaginationRequest) (*utils.PaginationResponse, error)
+	GetAllPaginated(query dto.EventListQuery, pagination utils.PaginationRequest) (*utils.PaginationResponse, error)
+	GetAllByCursor(query dto.EventListQuery, pagination utils.PaginationRequest) (*utils.CursorPaginationResponse, error)
 	GetByID(id uint) (*dto.EventResponse, error)
+	GetOwnerID(id uint) (uint, error)
 	Create(req dto.CreateEventRequest, createdBy uint) (*dto.EventResponse, error)
-	Update(id uint, req dto.UpdateEventRequest) (*dto.EventResponse, error)
+	Update(id uint, req dto.UpdateEventRequest, actorID uint) (*dto.EventResponse, error)
+	UpdateStatus(id uint, req dto.UpdateEventStatusRequest, actorID uint) (*dto.EventResponse, error)
+	GetStatusHistory(id uint) ([]dto.EventStatusHistoryResponse, error)
 	Delete(id uint) error
 }
 
 type eventService struct {
-	eventRepo repositories.EventRepository
+	txManager   repositories.TxManager
+	eventRepo   repositories.EventRepository
+	historyRepo repositories.EventStatusHistoryRepository
+	venueRepo   repositories.VenueRepository
+	tierRepo    repositories.TicketTierRepository
 }
 
-func NewEventService(eventRepo repositories.EventRepository) EventService {
+func NewEventService(txManager repositories.TxManager, eventRepo repositories.EventRepository, historyRepo repositories.EventStatusHistoryRepository, venueRepo repositories.VenueRepository, tierRepo repositories.TicketTierRepository) EventService {
 	return &eventService{
-		eventRepo: eventRepo,
+		txManager:   txManager,
+		eventRepo:   eventRepo,
+		historyRepo: historyRepo,
+		venueRepo:   venueRepo,
+		tierRepo:    tierRepo,
 	}
 }
 
@@ -36,37 +58,200 @@ func (s *eventService) GetAll() ([]dto.EventResponse, error) {
 
 	var eventResponses []dto.EventResponse
 	for _, event := range events {
-		eventResponses = append(eventResponses, s.entityToResponse(event))
+		eventResponses = append(eventResponses, eventToResponse(event))
 	}
 
 	return eventResponses, nil
 }
 
-func (s *eventService) GetAllPaginated(pagination utils.PaginationRequest) (*utils.PaginationResponse, error) {
-	events, total, err := s.eventRepo.GetAllPaginated(pagination.Offset, pagination.PageSize)
+func (s *eventService) GetAllPaginated(query dto.EventListQuery, pagination utils.PaginationRequest) (*utils.PaginationResponse, error) {
+	filter, err := buildEventFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	events, total, err := s.eventRepo.GetAllPaginated(*filter, pagination.Offset, pagination.PageSize)
 	if err != nil {
 		return nil, err
 	}
 
 	var eventResponses []dto.EventResponse
 	for _, event := range events {
-		eventResponses = append(eventResponses, s.entityToResponse(event))
+		eventResponses = append(eventResponses, eventToResponse(event))
 	}
 
 	response := utils.BuildPaginationResponse(eventResponses, total, pagination)
 	return &response, nil
 }
 
+func (s *eventService) GetAllByCursor(query dto.EventListQuery, pagination utils.PaginationRequest) (*utils.CursorPaginationResponse, error) {
+	filter, err := buildEventFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := utils.DecodeCursor(pagination.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyset *repositories.Keyset
+	if cursor != nil {
+		// The sort value in the cursor only makes sense for the same sort
+		if cursor.Sort != filter.Sort || cursor.Order != filter.Order {
+			return nil, errors.New("cursor does not match the requested sort")
+		}
+
+		value, err := parseEventSortValue(filter.Sort, cursor.Value)
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		keyset = &repositories.Keyset{Value: value, ID: cursor.ID, Backward: cursor.Backward}
+	}
+
+	events, hasMore, err := s.eventRepo.GetByCursor(*filter, keyset, pagination.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	eventResponses := make([]dto.EventResponse, 0, len(events))
+	for _, event := range events {
+		eventResponses = append(eventResponses, eventToResponse(event))
+	}
+
+	var first, last *utils.Cursor
+	if len(events) > 0 {
+		first = eventCursor(events[0], *filter)
+		last = eventCursor(events[len(events)-1], *filter)
+	}
+	next, prev := pageCursors(cursor, hasMore, first, last)
+
+	response := utils.BuildCursorResponse(eventResponses, pagination.PageSize, next, prev)
+	return &response, nil
+}
+
+// eventCursor builds the cursor pointing at event in the given sort.
+func eventCursor(event entities.Event, filter repositories.EventFilter) *utils.Cursor {
+	cursor := &utils.Cursor{Sort: filter.Sort, Order: filter.Order, ID: event.ID}
+
+	switch filter.Sort {
+	case "date":
+		cursor.Value = event.Date.Format(time.RFC3339Nano)
+	case "created_at":
+		cursor.Value = event.CreatedAt.Format(time.RFC3339Nano)
+	case "price":
+		cursor.Value = strconv.FormatFloat(event.Price, 'f', -1, 64)
+	case "sold_tickets":
+		cursor.Value = strconv.Itoa(event.SoldTickets)
+	}
+	return cursor
+}
+
+// parseEventSortValue reverses the encoding done by eventCursor.
+func parseEventSortValue(sort, value string) (interface{}, error) {
+	switch sort {
+	case "date", "created_at":
+		return time.Parse(time.RFC3339Nano, value)
+	case "price":
+		return strconv.ParseFloat(value, 64)
+	case "sold_tickets":
+		return strconv.Atoi(value)
+	}
+	return nil, nil
+}
+
+// buildEventFilter validates the listing query and turns it into a
+// repository filter.
+func buildEventFilter(query dto.EventListQuery) (*repositories.EventFilter, error) {
+	filter := repositories.EventFilter{
+		Category:        strings.TrimSpace(query.Category),
+		Location:        strings.TrimSpace(query.Location),
+		Search:          strings.TrimSpace(query.Search),
+		HasAvailability: query.HasAvailability,
+		MinPrice:        query.MinPrice,
+		MaxPrice:        query.MaxPrice,
+		Sort:            query.Sort,
+		Order:           strings.ToLower(query.Order),
+	}
+	if filter.Order == "" {
+		filter.Order = constants.SortOrderAsc
+	}
+
+	if query.Status != "" {
+		if !constants.IsValidEventStatus(query.Status) {
+			return nil, errors.New("invalid status")
+		}
+		filter.Status = query.Status
+	}
+
+	if query.DateFrom != "" {
+		dateFrom, err := parseFilterDate(query.DateFrom, false)
+		if err != nil {
+			return nil, errors.New("invalid date_from format")
+		}
+		filter.DateFrom = &dateFrom
+	}
+	if query.DateTo != "" {
+		dateTo, err := parseFilterDate(query.DateTo, true)
+		if err != nil {
+			return nil, errors.New("invalid date_to format")
+		}
+		filter.DateTo = &dateTo
+	}
+	if filter.DateFrom != nil && filter.DateTo != nil && filter.DateTo.Before(*filter.DateFrom) {
+		return nil, errors.New("date_to must be after date_from")
+	}
+
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MaxPrice < *filter.MinPrice {
+		return nil, errors.New("max_price must not be less than min_price")
+	}
+
+	if filter.Sort != "" && !repositories.IsValidEventSort(filter.Sort) {
+		return nil, errors.New("sort must be one of date, price, sold_tickets, created_at")
+	}
+	if filter.Order != constants.SortOrderAsc && filter.Order != constants.SortOrderDesc {
+		return nil, errors.New("order must be asc or desc")
+	}
+
+	return &filter, nil
+}
+
+// parseFilterDate accepts a full timestamp or a plain date. A plain date
+// used as an upper bound covers the whole day.
+func parseFilterDate(value string, endOfDay bool) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02T15:04:05Z", value); err == nil {
+		return date, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Second)
+	}
+	return date, nil
+}
+
 func (s *eventService) GetByID(id uint) (*dto.EventResponse, error) {
 	event, err := s.eventRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	response := s.entityToResponse(*event)
+	response := eventToResponse(*event)
 	return &response, nil
 }
 
+// GetOwnerID returns the ID of the user who created the event.
+func (s *eventService) GetOwnerID(id uint) (uint, error) {
+	event, err := s.eventRepo.GetByID(id)
+	if err != nil {
+		return 0, errors.New("event not found")
+	}
+	return event.CreatedBy, nil
+}
+
 func (s *eventService) Create(req dto.CreateEventRequest, createdBy uint) (*dto.EventResponse, error) {
 	// Parse dates
 	date, err := time.Parse("2006-01-02T15:04:05Z", req.Date)
@@ -97,26 +282,164 @@ func (s *eventService) Create(req dto.CreateEventRequest, createdBy uint) (*dto.
 		CreatedBy:   createdBy,
 		Status:      "upcoming",
 		IsActive:    true,
+		VenueID:     req.VenueID,
+		HallID:      req.HallID,
 	}
 
-	if err := s.eventRepo.Create(&event); err != nil {
+	if event.VenueID == nil {
+		if event.HallID != nil {
+			return nil, errors.New("hall requires a venue")
+		}
+		if event.Location == "" {
+			return nil, errors.New("location is required when no venue is set")
+		}
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		eventRepo := s.eventRepo.WithTx(tx)
+
+		if event.VenueID != nil {
+			venue, err := checkEventVenue(s.venueRepo.WithTx(tx), eventRepo, &event)
+			if err != nil {
+				return err
+			}
+			if event.Location == "" {
+				event.Location = venue.Name + ", " + venue.City
+			}
+		}
+
+		return eventRepo.Create(&event)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	response := s.entityToResponse(event)
+	response := eventToResponse(event)
 	return &response, nil
 }
 
-func (s *eventService) Update(id uint, req dto.UpdateEventRequest) (*dto.EventResponse, error) {
-	event, err := s.eventRepo.GetByID(id)
+func (s *eventService) Update(id uint, req dto.UpdateEventRequest, actorID uint) (*dto.EventResponse, error) {
+	var event *entities.Event
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		eventRepo := s.eventRepo.WithTx(tx)
+
+		var err error
+		event, err = eventRepo.GetByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		if time.Now().After(event.Date) {
+			return errors.New("cannot update past events")
+		}
+
+		if err := s.applyUpdate(event, req); err != nil {
+			return err
+		}
+
+		// The tiers must still fit once the capacity shrinks
+		if req.Capacity > 0 {
+			allocated, err := s.tierRepo.WithTx(tx).SumCapacityByEventID(event.ID, 0)
+			if err != nil {
+				return err
+			}
+			if event.Capacity < allocated {
+				return fmt.Errorf("capacity cannot be lower than the %d seats allocated to tiers", allocated)
+			}
+		}
+
+		// Dates and capacity may have changed, so the venue is always rechecked
+		if event.VenueID != nil {
+			venue, err := checkEventVenue(s.venueRepo.WithTx(tx), eventRepo, event)
+			if err != nil {
+				return err
+			}
+			if req.VenueID != nil && req.Location == "" {
+				event.Location = venue.Name + ", " + venue.City
+			}
+		}
+
+		// Status changes go through the state machine, which also saves
+		if req.Status != "" && req.Status != event.Status {
+			if req.Status == constants.EventStatusCancelled {
+				return errCancelThroughEndpoint
+			}
+			return transitionEvent(eventRepo, s.historyRepo.WithTx(tx), event, req.Status, &actorID, req.StatusReason, time.Now())
+		}
+
+		return eventRepo.Update(event)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if time.Now().After(event.Date) {
-		return nil, errors.New("cannot update past events")
+	response := eventToResponse(*event)
+	return &response, nil
+}
+
+// UpdateStatus moves an event to another status. Unlike Update it also
+// works once the event has started, so it can be completed.
+func (s *eventService) UpdateStatus(id uint, req dto.UpdateEventStatusRequest, actorID uint) (*dto.EventResponse, error) {
+	var event *entities.Event
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		eventRepo := s.eventRepo.WithTx(tx)
+
+		var err error
+		event, err = eventRepo.GetByIDForUpdate(id)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		if req.Status == constants.EventStatusCancelled {
+			return errCancelThroughEndpoint
+		}
+
+		return transitionEvent(eventRepo, s.historyRepo.WithTx(tx), event, req.Status, &actorID, req.Reason, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := eventToResponse(*event)
+	return &response, nil
+}
+
+func (s *eventService) GetStatusHistory(id uint) ([]dto.EventStatusHistoryResponse, error) {
+	if _, err := s.eventRepo.GetByID(id); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	history, err := s.historyRepo.GetByEventID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	historyResponses := make([]dto.EventStatusHistoryResponse, 0, len(history))
+	for _, entry := range history {
+		response := dto.EventStatusHistoryResponse{
+			ID:         entry.ID,
+			EventID:    entry.EventID,
+			FromStatus: entry.FromStatus,
+			ToStatus:   entry.ToStatus,
+			ActorID:    entry.ActorID,
+			ActorName:  "system",
+			Reason:     entry.Reason,
+			ChangedAt:  entry.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if entry.Actor != nil {
+			response.ActorName = entry.Actor.Name
+		}
+		historyResponses = append(historyResponses, response)
 	}
 
+	return historyResponses, nil
+}
+
+// applyUpdate copies the provided fields of req onto event. Status is left
+// to the caller.
+func (s *eventService) applyUpdate(event *entities.Event, req dto.UpdateEventRequest) error {
 	// Update fields if provided
 	if req.Title != "" {
 		event.Title = req.Title
@@ -133,33 +456,47 @@ func (s *eventService) Update(id uint, req dto.UpdateEventRequest) (*dto.EventRe
 	if req.Date != "" {
 		date, err := time.Parse("2006-01-02T15:04:05Z", req.Date)
 		if err != nil {
-			return nil, errors.New("invalid date format")
+			return errors.New("invalid date format")
 		}
 		event.Date = date
 	}
 	if req.EndDate != "" {
 		endDate, err := time.Parse("2006-01-02T15:04:05Z", req.EndDate)
 		if err != nil {
-			return nil, errors.New("invalid end date format")
+			return errors.New("invalid end date format")
 		}
 		event.EndDate = endDate
 	}
 	if req.Capacity > 0 {
+		if req.Capacity < event.SoldTickets {
+			return fmt.Errorf("capacity cannot be lower than the %d tickets already sold", event.SoldTickets)
+		}
 		event.Capacity = req.Capacity
 	}
 	if req.Price >= 0 {
 		event.Price = req.Price
 	}
-	if req.Status != "" {
-		event.Status = req.Status
+	if req.VenueID != nil {
+		// Moving to another venue drops the old hall unless a new one is given
+		if *req.VenueID == 0 {
+			event.VenueID = nil
+		} else if event.VenueID == nil || *event.VenueID != *req.VenueID {
+			event.VenueID = req.VenueID
+			event.HallID = nil
+		}
 	}
-
-	if err := s.eventRepo.Update(event); err != nil {
-		return nil, err
+	if req.HallID != nil {
+		if *req.HallID == 0 {
+			event.HallID = nil
+		} else {
+			event.HallID = req.HallID
+		}
+	}
+	if event.VenueID == nil {
+		event.HallID = nil
 	}
 
-	response := s.entityToResponse(*event)
-	return &response, nil
+	return nil
 }
 
 func (s *eventService) Delete(id uint) error {
@@ -175,7 +512,7 @@ func (s *eventService) Delete(id uint) error {
 	return s.eventRepo.Delete(event)
 }
 
-func (s *eventService) entityToResponse(event entities.Event) dto.EventResponse {
+func eventToResponse(event entities.Event) dto.EventResponse {
 	return dto.EventResponse{
 		ID:          event.ID,
 		Title:       event.Title,
@@ -190,5 +527,8 @@ func (s *eventService) entityToResponse(event entities.Event) dto.EventResponse
 		SoldTickets: event.SoldTickets,
 		CreatedBy:   event.CreatedBy,
 		IsActive:    event.IsActive,
+		VenueID:     event.VenueID,
+		HallID:      event.HallID,
+		SeriesID:    event.SeriesID,
 	}
 }
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"fmt"
+	"time"
+)
+
+// eventTransitions lists the statuses each event status may move to.
+// Completed and cancelled are final.
+var eventTransitions = map[string][]string{
+	constants.EventStatusUpcoming:  {constants.EventStatusOngoing, constants.EventStatusCancelled},
+	constants.EventStatusOngoing:   {constants.EventStatusCompleted, constants.EventStatusCancelled},
+	constants.EventStatusCompleted: {},
+	constants.EventStatusCancelled: {},
+}
+
+// checkEventTransition reports why event cannot move to status at now, or
+// nil when the move is legal.
+func checkEventTransition(event *entities.Event, status string, now time.Time) error {
+	if !constants.IsValidEventStatus(status) {
+		return fmt.Errorf("invalid event status: %s", status)
+	}
+	if event.Status == status {
+		return fmt.Errorf("event is already %s", status)
+	}
+
+	allowed := false
+	for _, next := range eventTransitions[event.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("event cannot move from %s to %s", event.Status, status)
+	}
+
+	switch status {
+	case constants.EventStatusOngoing:
+		if now.Before(event.Date) {
+			return fmt.Errorf("event cannot start before its start date %s", event.Date.Format("2006-01-02T15:04:05Z"))
+		}
+	case constants.EventStatusCompleted:
+		endDate := event.EndDate
+		if endDate.Before(event.Date) {
+			endDate = event.Date
+		}
+		if now.Before(endDate) {
+			return fmt.Errorf("event cannot complete before its end date %s", endDate.Format("2006-01-02T15:04:05Z"))
+		}
+	}
+
+	return nil
+}
+
+// transitionEvent moves event to status and records the change in the
+// status history. actorID is nil for system changes. Callers run it inside
+// a transaction with event locked and pass repositories bound to it.
+func transitionEvent(eventRepo repositories.EventRepository, historyRepo repositories.EventStatusHistoryRepository, event *entities.Event, status string, actorID *uint, reason string, now time.Time) error {
+	if err := checkEventTransition(event, status, now); err != nil {
+		return err
+	}
+
+	history := entities.EventStatusHistory{
+		EventID:    event.ID,
+		FromStatus: event.Status,
+		ToStatus:   status,
+		ActorID:    actorID,
+		Reason:     reason,
+	}
+
+	event.Status = status
+	if err := eventRepo.Update(event); err != nil {
+		return err
+	}
+	return historyRepo.Create(&history)
+}
@@ -0,0 +1,29 @@
+package services
+
+import "case_study_api/utils"
+
+// pageCursors works out next_cursor and prev_cursor for a page read from
+// cursor. first and last point at the first and last rows of the page and
+// are nil when the page is empty.
+func pageCursors(cursor *utils.Cursor, hasMore bool, first, last *utils.Cursor) (*string, *string) {
+	if first == nil || last == nil {
+		return nil, nil
+	}
+
+	var next, prev *string
+	backward := cursor != nil && cursor.Backward
+
+	// A backward page was reached from the page after it, so that page exists
+	if backward || hasMore {
+		value := utils.EncodeCursor(*last)
+		next = &value
+	}
+	if (cursor != nil && !backward) || (backward && hasMore) {
+		before := *first
+		before.Backward = true
+		value := utils.EncodeCursor(before)
+		prev = &value
+	}
+
+	return next, prev
+}
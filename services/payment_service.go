@@ -0,0 +1,160 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/payments"
+	"case_study_api/repositories"
+	"case_study_api/utils"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+type PaymentService interface {
+	HandleCallback(gatewayName string, payload []byte, headers http.Header) error
+	GetUserOrders(userID uint, pagination utils.PaginationRequest) (*utils.PaginationResponse, error)
+	GetOrder(orderID uint, userID uint, isAdmin bool) (*dto.OrderResponse, error)
+}
+
+type paymentService struct {
+	orderRepo     repositories.OrderRepository
+	paymentRepo   repositories.PaymentRepository
+	ticketService TicketService
+	gateways      map[string]payments.Gateway
+}
+
+func NewPaymentService(orderRepo repositories.OrderRepository, paymentRepo repositories.PaymentRepository, ticketService TicketService, gateways ...payments.Gateway) PaymentService {
+	registry := make(map[string]payments.Gateway, len(gateways))
+	for _, gateway := range gateways {
+		registry[gateway.Name()] = gateway
+	}
+
+	return &paymentService{
+		orderRepo:     orderRepo,
+		paymentRepo:   paymentRepo,
+		ticketService: ticketService,
+		gateways:      registry,
+	}
+}
+
+// HandleCallback verifies a gateway webhook and settles the order it refers
+// to. Callbacks for orders that were already settled are accepted and ignored,
+// except a payment captured after its order expired or failed, which is
+// refunded.
+func (s *paymentService) HandleCallback(gatewayName string, payload []byte, headers http.Header) error {
+	gateway, ok := s.gateways[gatewayName]
+	if !ok {
+		return fmt.Errorf("unknown payment gateway: %s", gatewayName)
+	}
+
+	event, err := gateway.ParseCallback(payload, headers.Get(gateway.SignatureHeader()))
+	if err != nil {
+		return err
+	}
+
+	payment, err := s.paymentRepo.GetByReference(event.Reference)
+	if err != nil {
+		return errors.New("payment not found")
+	}
+
+	if payment.Gateway != gateway.Name() {
+		return errors.New("payment belongs to a different gateway")
+	}
+
+	if err := s.paymentRepo.RecordCallback(payment.ID, string(payload)); err != nil {
+		return err
+	}
+
+	switch event.Status {
+	case payments.StatusPaid:
+		if toCents(event.Amount) != toCents(payment.Amount) {
+			log.Printf("Payment %s reported %.2f but %.2f was charged", payment.Reference, event.Amount, payment.Amount)
+			return errors.New("payment amount mismatch")
+		}
+		return s.ticketService.SettleOrder(payment.OrderID, constants.PaymentStatusPaid)
+	case payments.StatusFailed:
+		return s.ticketService.SettleOrder(payment.OrderID, constants.PaymentStatusFailed)
+	case payments.StatusPending:
+		return nil
+	default:
+		return fmt.Errorf("unknown payment status: %s", event.Status)
+	}
+}
+
+func (s *paymentService) GetUserOrders(userID uint, pagination utils.PaginationRequest) (*utils.PaginationResponse, error) {
+	orders, total, err := s.orderRepo.GetByUserIDPaginated(userID, pagination.Offset, pagination.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderResponses []dto.OrderResponse
+	for _, order := range orders {
+		orderResponses = append(orderResponses, s.entityToResponse(order))
+	}
+
+	response := utils.BuildPaginationResponse(orderResponses, total, pagination)
+	return &response, nil
+}
+
+func (s *paymentService) GetOrder(orderID uint, userID uint, isAdmin bool) (*dto.OrderResponse, error) {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, errors.New("order not found")
+	}
+
+	if order.UserID != userID && !isAdmin {
+		return nil, errors.New("unauthorized access")
+	}
+
+	response := s.entityToResponse(*order)
+	return &response, nil
+}
+
+func (s *paymentService) entityToResponse(order entities.Order) dto.OrderResponse {
+	response := orderToResponse(order)
+
+	if order.Ticket.ID != 0 {
+		ticketResponse := ticketToResponse(order.Ticket)
+		response.Ticket = &ticketResponse
+	}
+
+	return response
+}
+
+func orderToResponse(order entities.Order) dto.OrderResponse {
+	response := dto.OrderResponse{
+		ID:        order.ID,
+		TicketID:  order.TicketID,
+		Amount:    order.Amount,
+		Status:    order.Status,
+		ExpiresAt: order.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+		CreatedAt: order.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		Payments:  make([]dto.PaymentResponse, 0, len(order.Payments)),
+	}
+
+	if order.PaidAt != nil {
+		paidAt := order.PaidAt.Format("2006-01-02T15:04:05Z")
+		response.PaidAt = &paidAt
+	}
+
+	for _, payment := range order.Payments {
+		paymentResponse := dto.PaymentResponse{
+			ID:         payment.ID,
+			Gateway:    payment.Gateway,
+			Reference:  payment.Reference,
+			Amount:     payment.Amount,
+			Status:     payment.Status,
+			PaymentURL: payment.PaymentURL,
+		}
+		if payment.PaidAt != nil {
+			paidAt := payment.PaidAt.Format("2006-01-02T15:04:05Z")
+			paymentResponse.PaidAt = &paidAt
+		}
+		response.Payments = append(response.Payments, paymentResponse)
+	}
+
+	return response
+}
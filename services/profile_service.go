@@ -0,0 +1,196 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"case_study_api/utils"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type ProfileService interface {
+	GetProfile(userID uint) (*dto.ProfileResponse, error)
+	UpdateProfile(userID uint, req dto.UpdateProfileRequest) (*dto.ProfileResponse, error)
+	ChangePassword(userID uint, req dto.ChangePasswordRequest) error
+	DeleteAccount(userID uint, tokenID string, tokenExpiresAt time.Time, req dto.DeleteAccountRequest) error
+}
+
+type profileService struct {
+	txManager     repositories.TxManager
+	userRepo      repositories.UserRepository
+	refreshRepo   repositories.RefreshTokenRepository
+	revokedRepo   repositories.RevokedTokenRepository
+	userTokenRepo repositories.UserTokenRepository
+	roleRepo      repositories.RoleRepository
+	authService   AuthService
+}
+
+func NewProfileService(txManager repositories.TxManager, userRepo repositories.UserRepository, refreshRepo repositories.RefreshTokenRepository, revokedRepo repositories.RevokedTokenRepository, userTokenRepo repositories.UserTokenRepository, roleRepo repositories.RoleRepository, authService AuthService) ProfileService {
+	return &profileService{
+		txManager:     txManager,
+		userRepo:      userRepo,
+		refreshRepo:   refreshRepo,
+		revokedRepo:   revokedRepo,
+		userTokenRepo: userTokenRepo,
+		roleRepo:      roleRepo,
+		authService:   authService,
+	}
+}
+
+func (s *profileService) GetProfile(userID uint) (*dto.ProfileResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	return userToProfileResponse(user), nil
+}
+
+func (s *profileService) UpdateProfile(userID uint, req dto.UpdateProfileRequest) (*dto.ProfileResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		user.Name = name
+	}
+	if req.Phone != nil {
+		user.Phone = strings.TrimSpace(*req.Phone)
+	}
+
+	emailChanged := false
+	if email := strings.ToLower(req.Email); email != "" && email != user.Email {
+		existing, _ := s.userRepo.FindByEmail(email)
+		if existing != nil && existing.ID != 0 {
+			return nil, errors.New("email is already registered")
+		}
+		user.Email = email
+		user.EmailVerifiedAt = nil
+		emailChanged = true
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	// The new address has to be verified like a fresh registration
+	if emailChanged {
+		if err := s.authService.ResendVerification(user.ID); err != nil {
+			log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+		}
+	}
+
+	return userToProfileResponse(user), nil
+}
+
+// ChangePassword replaces the password after checking the current one and
+// signs the user out of every session by revoking their refresh tokens.
+func (s *profileService) ChangePassword(userID uint, req dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return errors.New("current password is incorrect")
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.userRepo.WithTx(tx).UpdatePassword(user.ID, string(hashed)); err != nil {
+			return err
+		}
+		return s.refreshRepo.WithTx(tx).RevokeAllForUser(user.ID, time.Now())
+	})
+}
+
+// DeleteAccount anonymizes the user instead of deleting the row: personal
+// data is overwritten and the account can no longer sign in, while its
+// tickets, orders and payments stay in place for financial reporting.
+func (s *profileService) DeleteAccount(userID uint, tokenID string, tokenExpiresAt time.Time, req dto.DeleteAccountRequest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return errors.New("password is incorrect")
+	}
+
+	// Accounts that administer users or roles must be handed over first,
+	// whatever their role is called
+	permissions, err := s.roleRepo.GetPermissionNames(user.Role)
+	if err != nil {
+		return err
+	}
+	for _, permission := range permissions {
+		if permission == constants.PermissionUsersManage || permission == constants.PermissionRolesManage {
+			return errors.New("accounts that manage users or roles cannot be deleted")
+		}
+	}
+
+	// Nobody knows the replacement password, so it can never match
+	unusable, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return errors.New("failed to generate token")
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(unusable), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("failed to hash password")
+	}
+	now := time.Now()
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		user.Name = "Deleted User"
+		user.Email = fmt.Sprintf("deleted-%d@deleted.invalid", user.ID)
+		user.Phone = ""
+		user.Password = string(hashed)
+		user.CalendarTokenHash = nil
+		user.EmailVerifiedAt = nil
+		user.AnonymizedAt = &now
+		if err := s.userRepo.WithTx(tx).Update(user); err != nil {
+			return err
+		}
+
+		userTokenRepo := s.userTokenRepo.WithTx(tx)
+		if err := userTokenRepo.InvalidateForUser(user.ID, constants.TokenPurposeVerifyEmail, now); err != nil {
+			return err
+		}
+		if err := userTokenRepo.InvalidateForUser(user.ID, constants.TokenPurposeResetPassword, now); err != nil {
+			return err
+		}
+		if err := s.refreshRepo.WithTx(tx).RevokeAllForUser(user.ID, now); err != nil {
+			return err
+		}
+
+		return s.revokedRepo.WithTx(tx).Create(&entities.RevokedToken{
+			JTI:       tokenID,
+			UserID:    user.ID,
+			ExpiresAt: tokenExpiresAt,
+		})
+	})
+}
+
+func userToProfileResponse(user *entities.User) *dto.ProfileResponse {
+	return &dto.ProfileResponse{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
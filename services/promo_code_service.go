@@ -0,0 +1,288 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+type PromoCodeService interface {
+	GetAll() ([]dto.PromoCodeResponse, error)
+	GetByID(id uint) (*dto.PromoCodeResponse, error)
+	Create(req dto.CreatePromoCodeRequest) (*dto.PromoCodeResponse, error)
+	Update(id uint, req dto.UpdatePromoCodeRequest) (*dto.PromoCodeResponse, error)
+	Delete(id uint) error
+	GetRedemptionReport(id uint) (*dto.PromoRedemptionReportResponse, error)
+}
+
+type promoCodeService struct {
+	promoRepo repositories.PromoCodeRepository
+	eventRepo repositories.EventRepository
+}
+
+func NewPromoCodeService(promoRepo repositories.PromoCodeRepository, eventRepo repositories.EventRepository) PromoCodeService {
+	return &promoCodeService{
+		promoRepo: promoRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *promoCodeService) GetAll() ([]dto.PromoCodeResponse, error) {
+	promos, err := s.promoRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	promoResponses := make([]dto.PromoCodeResponse, 0, len(promos))
+	for _, promo := range promos {
+		promoResponses = append(promoResponses, s.entityToResponse(promo))
+	}
+
+	return promoResponses, nil
+}
+
+func (s *promoCodeService) GetByID(id uint) (*dto.PromoCodeResponse, error) {
+	promo, err := s.promoRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("promo code not found")
+	}
+
+	response := s.entityToResponse(*promo)
+	return &response, nil
+}
+
+func (s *promoCodeService) Create(req dto.CreatePromoCodeRequest) (*dto.PromoCodeResponse, error) {
+	if req.EventID != nil {
+		if _, err := s.eventRepo.GetByID(*req.EventID); err != nil {
+			return nil, errors.New("event not found")
+		}
+	}
+
+	validFrom, validUntil, err := parseValidityWindow(req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	minQuantity := req.MinQuantity
+	if minQuantity == 0 {
+		minQuantity = 1
+	}
+
+	promo := entities.PromoCode{
+		Code:           normalizePromoCode(req.Code),
+		Description:    req.Description,
+		DiscountType:   req.DiscountType,
+		DiscountValue:  req.DiscountValue,
+		EventID:        req.EventID,
+		ValidFrom:      validFrom,
+		ValidUntil:     validUntil,
+		MaxRedemptions: req.MaxRedemptions,
+		PerUserLimit:   req.PerUserLimit,
+		MinQuantity:    minQuantity,
+		IsActive:       true,
+	}
+
+	if err := validatePromoDiscount(&promo); err != nil {
+		return nil, err
+	}
+
+	if err := s.promoRepo.Create(&promo); err != nil {
+		return nil, errors.New("promo code already exists")
+	}
+
+	response := s.entityToResponse(promo)
+	return &response, nil
+}
+
+func (s *promoCodeService) Update(id uint, req dto.UpdatePromoCodeRequest) (*dto.PromoCodeResponse, error) {
+	promo, err := s.promoRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("promo code not found")
+	}
+
+	// Update fields if provided
+	if req.Description != "" {
+		promo.Description = req.Description
+	}
+	if req.DiscountType != "" {
+		promo.DiscountType = req.DiscountType
+	}
+	if req.DiscountValue != nil {
+		promo.DiscountValue = *req.DiscountValue
+	}
+	if req.MaxRedemptions != nil {
+		promo.MaxRedemptions = *req.MaxRedemptions
+	}
+	if req.PerUserLimit != nil {
+		promo.PerUserLimit = *req.PerUserLimit
+	}
+	if req.MinQuantity != nil {
+		promo.MinQuantity = *req.MinQuantity
+	}
+	if req.IsActive != nil {
+		promo.IsActive = *req.IsActive
+	}
+
+	if err := validatePromoDiscount(promo); err != nil {
+		return nil, err
+	}
+
+	validFrom, validUntil := req.ValidFrom, req.ValidUntil
+	if validFrom == "" && promo.ValidFrom != nil {
+		validFrom = promo.ValidFrom.Format("2006-01-02T15:04:05Z")
+	}
+	if validUntil == "" && promo.ValidUntil != nil {
+		validUntil = promo.ValidUntil.Format("2006-01-02T15:04:05Z")
+	}
+	promo.ValidFrom, promo.ValidUntil, err = parseValidityWindow(validFrom, validUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.promoRepo.Update(promo); err != nil {
+		return nil, err
+	}
+
+	response := s.entityToResponse(*promo)
+	return &response, nil
+}
+
+func (s *promoCodeService) Delete(id uint) error {
+	promo, err := s.promoRepo.GetByID(id)
+	if err != nil {
+		return errors.New("promo code not found")
+	}
+
+	// Used codes stay for the redemption report, deactivate them instead
+	tickets, err := s.promoRepo.CountTickets(promo.ID)
+	if err != nil {
+		return err
+	}
+	if tickets > 0 {
+		return errors.New("cannot delete a promo code that was used, deactivate it instead")
+	}
+
+	return s.promoRepo.Delete(promo)
+}
+
+func (s *promoCodeService) GetRedemptionReport(id uint) (*dto.PromoRedemptionReportResponse, error) {
+	promo, err := s.promoRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("promo code not found")
+	}
+
+	summary, err := s.promoRepo.GetRedemptionSummary(promo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	redemptions, err := s.promoRepo.GetRedemptions(promo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.PromoRedemptionReportResponse{
+		PromoCode:        s.entityToResponse(*promo),
+		TotalRedemptions: summary.TotalRedemptions,
+		UniqueUsers:      summary.UniqueUsers,
+		TotalDiscount:    summary.TotalDiscount,
+		TicketsSold:      summary.TicketsSold,
+		Revenue:          summary.Revenue,
+		Redemptions:      make([]dto.PromoRedemptionResponse, 0, len(redemptions)),
+	}
+
+	for _, redemption := range redemptions {
+		response.Redemptions = append(response.Redemptions, dto.PromoRedemptionResponse{
+			ID:          redemption.ID,
+			UserID:      redemption.UserID,
+			UserName:    redemption.User.Name,
+			TicketID:    redemption.TicketID,
+			BookingCode: redemption.Ticket.BookingCode,
+			Discount:    redemption.Discount,
+			Status:      redemption.Status,
+			RedeemedAt:  redemption.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return &response, nil
+}
+
+func (s *promoCodeService) entityToResponse(promo entities.PromoCode) dto.PromoCodeResponse {
+	response := dto.PromoCodeResponse{
+		ID:             promo.ID,
+		Code:           promo.Code,
+		Description:    promo.Description,
+		DiscountType:   promo.DiscountType,
+		DiscountValue:  promo.DiscountValue,
+		EventID:        promo.EventID,
+		MaxRedemptions: promo.MaxRedemptions,
+		PerUserLimit:   promo.PerUserLimit,
+		MinQuantity:    promo.MinQuantity,
+		Redemptions:    promo.Redemptions,
+		IsActive:       promo.IsActive,
+		CreatedAt:      promo.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if promo.ValidFrom != nil {
+		validFrom := promo.ValidFrom.Format("2006-01-02T15:04:05Z")
+		response.ValidFrom = &validFrom
+	}
+	if promo.ValidUntil != nil {
+		validUntil := promo.ValidUntil.Format("2006-01-02T15:04:05Z")
+		response.ValidUntil = &validUntil
+	}
+
+	return response
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validatePromoDiscount(promo *entities.PromoCode) error {
+	if promo.DiscountType == constants.DiscountTypePercentage && promo.DiscountValue > 100 {
+		return errors.New("percentage discount cannot exceed 100")
+	}
+	return nil
+}
+
+func parseValidityWindow(from, until string) (*time.Time, *time.Time, error) {
+	var validFrom, validUntil *time.Time
+
+	if from != "" {
+		parsed, err := time.Parse("2006-01-02T15:04:05Z", from)
+		if err != nil {
+			return nil, nil, errors.New("invalid valid from format")
+		}
+		validFrom = &parsed
+	}
+
+	if until != "" {
+		parsed, err := time.Parse("2006-01-02T15:04:05Z", until)
+		if err != nil {
+			return nil, nil, errors.New("invalid valid until format")
+		}
+		validUntil = &parsed
+	}
+
+	if validFrom != nil && validUntil != nil && validUntil.Before(*validFrom) {
+		return nil, nil, errors.New("valid until must be after valid from")
+	}
+
+	return validFrom, validUntil, nil
+}
+
+// promoDiscount works out what a code takes off subtotal, rounded to cents
+// and never more than the subtotal itself.
+func promoDiscount(promo *entities.PromoCode, subtotal float64) float64 {
+	discount := promo.DiscountValue
+	if promo.DiscountType == constants.DiscountTypePercentage {
+		discount = math.Round(subtotal*promo.DiscountValue) / 100
+	}
+	return math.Min(discount, subtotal)
+}
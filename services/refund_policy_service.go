@@ -0,0 +1,120 @@
+package services
+
+import (
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type RefundPolicyService interface {
+	GetPolicy(eventID uint) (*dto.RefundPolicyResponse, error)
+	UpdatePolicy(eventID uint, req dto.UpdateRefundPolicyRequest) (*dto.RefundPolicyResponse, error)
+}
+
+type refundPolicyService struct {
+	policyRepo repositories.RefundPolicyRepository
+	eventRepo  repositories.EventRepository
+}
+
+func NewRefundPolicyService(policyRepo repositories.RefundPolicyRepository, eventRepo repositories.EventRepository) RefundPolicyService {
+	return &refundPolicyService{
+		policyRepo: policyRepo,
+		eventRepo:  eventRepo,
+	}
+}
+
+func (s *refundPolicyService) GetPolicy(eventID uint) (*dto.RefundPolicyResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	rules, err := s.policyRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(eventID, rules), nil
+}
+
+// UpdatePolicy replaces the refund policy of an event. An empty rule list
+// restores the default of refunding every cancellation in full.
+func (s *refundPolicyService) UpdatePolicy(eventID uint, req dto.UpdateRefundPolicyRequest) (*dto.RefundPolicyResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	requested := make([]dto.RefundPolicyRule, len(req.Rules))
+	copy(requested, req.Rules)
+	sort.Slice(requested, func(i, j int) bool {
+		return requested[i].MinHoursBefore > requested[j].MinHoursBefore
+	})
+
+	// Cancelling later must never pay back more than cancelling earlier
+	rules := make([]entities.RefundPolicyRule, 0, len(requested))
+	for i, rule := range requested {
+		if i > 0 {
+			previous := requested[i-1]
+			if rule.MinHoursBefore == previous.MinHoursBefore {
+				return nil, fmt.Errorf("duplicate rule for %d hours before the event", rule.MinHoursBefore)
+			}
+			if rule.Percentage > previous.Percentage {
+				return nil, fmt.Errorf("rule for %d hours refunds more than the rule for %d hours", rule.MinHoursBefore, previous.MinHoursBefore)
+			}
+		}
+		rules = append(rules, entities.RefundPolicyRule{
+			EventID:        eventID,
+			MinHoursBefore: rule.MinHoursBefore,
+			Percentage:     rule.Percentage,
+		})
+	}
+
+	if err := s.policyRepo.ReplaceForEvent(eventID, rules); err != nil {
+		return nil, err
+	}
+
+	return s.entityToResponse(eventID, rules), nil
+}
+
+func (s *refundPolicyService) entityToResponse(eventID uint, rules []entities.RefundPolicyRule) *dto.RefundPolicyResponse {
+	response := &dto.RefundPolicyResponse{
+		EventID: eventID,
+		Rules:   make([]dto.RefundPolicyRule, 0, len(rules)),
+		Default: len(rules) == 0,
+	}
+
+	for _, rule := range rules {
+		response.Rules = append(response.Rules, dto.RefundPolicyRule{
+			MinHoursBefore: rule.MinHoursBefore,
+			Percentage:     rule.Percentage,
+		})
+	}
+
+	return response
+}
+
+// refundPercentage applies a policy, ordered by MinHoursBefore descending, to
+// a cancellation made at now. Events without rules refund in full; once the
+// last window has passed nothing is refunded.
+func refundPercentage(rules []entities.RefundPolicyRule, eventDate time.Time, now time.Time) float64 {
+	if len(rules) == 0 {
+		return 100
+	}
+
+	hoursBefore := eventDate.Sub(now).Hours()
+	for _, rule := range rules {
+		if hoursBefore >= float64(rule.MinHoursBefore) {
+			return rule.Percentage
+		}
+	}
+	return 0
+}
+
+// refundAmount returns percentage of total, rounded to cents.
+func refundAmount(total float64, percentage float64) float64 {
+	return math.Round(total*percentage) / 100
+}
@@ -4,15 +4,18 @@ import (
 	"bytes"
 	"case_study_api/dto"
 	"case_study_api/repositories"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/jung-kurt/gofpdf"
+	"gorm.io/gorm"
 )
 
 type ReportService interface {
 	GetSummary() (*dto.SummaryReportResponse, error)
 	GetEventReport(eventID uint) (*dto.EventReportResponse, error)
+	GetSeriesReport(seriesID uint) (*dto.SeriesReportResponse, error)
 	GetSystemReport() (*dto.SystemReportResponse, error)
 	GenerateSystemReportPDF() ([]byte, error)
 }
@@ -45,14 +48,66 @@ func (s *reportService) GetEventReport(eventID uint) (*dto.EventReportResponse,
 		return nil, err
 	}
 
+	tiers, err := s.reportRepo.GetEventTierBreakdown(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	tiersDTO := make([]dto.TierSalesReport, len(tiers))
+	for i, tier := range tiers {
+		tiersDTO[i] = dto.TierSalesReport{
+			TierID:      tier.TierID,
+			Name:        tier.Name,
+			Price:       tier.Price,
+			Capacity:    tier.Capacity,
+			TicketsSold: tier.TicketsSold,
+			Revenue:     tier.Revenue,
+		}
+	}
+
 	return &dto.EventReportResponse{
 		EventID:     report.EventID,
 		Title:       report.Title,
 		TicketsSold: report.TicketsSold,
 		Revenue:     report.Revenue,
+		Tiers:       tiersDTO,
 	}, nil
 }
 
+// GetSeriesReport rolls up the sales of every occurrence of a series.
+func (s *reportService) GetSeriesReport(seriesID uint) (*dto.SeriesReportResponse, error) {
+	report, err := s.reportRepo.GetSeriesReport(seriesID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("series not found")
+		}
+		return nil, err
+	}
+
+	response := dto.SeriesReportResponse{
+		SeriesID:    report.SeriesID,
+		Title:       report.Title,
+		Occurrences: len(report.Events),
+		Events:      make([]dto.SeriesOccurrenceReport, 0, len(report.Events)),
+	}
+	for _, event := range report.Events {
+		response.TotalCapacity += event.Capacity
+		response.TicketsSold += event.TicketsSold
+		response.Revenue += event.Revenue
+		response.Events = append(response.Events, dto.SeriesOccurrenceReport{
+			EventID:     event.EventID,
+			Title:       event.Title,
+			Date:        event.Date.Format("2006-01-02T15:04:05Z"),
+			Status:      event.Status,
+			Capacity:    event.Capacity,
+			TicketsSold: event.TicketsSold,
+			Revenue:     event.Revenue,
+		})
+	}
+
+	return &response, nil
+}
+
 func (s *reportService) GetSystemReport() (*dto.SystemReportResponse, error) {
 	// Get all the different metrics
 	overview, err := s.reportRepo.GetSystemOverview()
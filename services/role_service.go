@@ -0,0 +1,219 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+type RoleService interface {
+	GetRoles() ([]dto.RoleResponse, error)
+	GetRole(id uint) (*dto.RoleResponse, error)
+	GetPermissions() ([]dto.PermissionResponse, error)
+	CreateRole(req dto.CreateRoleRequest) (*dto.RoleResponse, error)
+	UpdateRole(id uint, req dto.UpdateRoleRequest) (*dto.RoleResponse, error)
+	DeleteRole(id uint) error
+	GetRolePermissions(role string) ([]string, error)
+}
+
+type roleService struct {
+	txManager      repositories.TxManager
+	roleRepo       repositories.RoleRepository
+	permissionRepo repositories.PermissionRepository
+}
+
+func NewRoleService(txManager repositories.TxManager, roleRepo repositories.RoleRepository, permissionRepo repositories.PermissionRepository) RoleService {
+	return &roleService{
+		txManager:      txManager,
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+	}
+}
+
+func (s *roleService) GetRoles() ([]dto.RoleResponse, error) {
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	roleResponses := make([]dto.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		roleResponses = append(roleResponses, roleToResponse(&role))
+	}
+	return roleResponses, nil
+}
+
+func (s *roleService) GetRole(id uint) (*dto.RoleResponse, error) {
+	role, err := s.roleRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("role not found")
+	}
+
+	response := roleToResponse(role)
+	return &response, nil
+}
+
+func (s *roleService) GetPermissions() ([]dto.PermissionResponse, error) {
+	permissions, err := s.permissionRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	permissionResponses := make([]dto.PermissionResponse, 0, len(permissions))
+	for _, permission := range permissions {
+		permissionResponses = append(permissionResponses, dto.PermissionResponse{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+	return permissionResponses, nil
+}
+
+func (s *roleService) CreateRole(req dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if !roleNamePattern.MatchString(name) {
+		return nil, errors.New("role name must be 2-50 lowercase letters, digits or underscores, starting with a letter")
+	}
+	if existing, _ := s.roleRepo.GetByName(name); existing != nil {
+		return nil, errors.New("role already exists")
+	}
+
+	permissions, err := s.resolvePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := entities.Role{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	}
+	if err := s.roleRepo.Create(&role); err != nil {
+		return nil, err
+	}
+
+	return s.GetRole(role.ID)
+}
+
+// UpdateRole changes a role's description and permissions. The changes
+// apply to its users on their next request. The admin role always keeps
+// every permission, so nobody can lock the admins out.
+func (s *roleService) UpdateRole(id uint, req dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	role, err := s.roleRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("role not found")
+	}
+	if req.Permissions != nil && role.Name == constants.UserRoleAdmin {
+		return nil, errors.New("the permissions of the admin role cannot be changed")
+	}
+
+	var permissions []entities.Permission
+	if req.Permissions != nil {
+		permissions, err = s.resolvePermissions(req.Permissions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		roleRepo := s.roleRepo.WithTx(tx)
+
+		if req.Description != nil {
+			role.Description = strings.TrimSpace(*req.Description)
+			if err := roleRepo.Update(role); err != nil {
+				return err
+			}
+		}
+		if req.Permissions != nil {
+			return roleRepo.ReplacePermissions(role, permissions)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetRole(role.ID)
+}
+
+// DeleteRole removes a custom role that no user holds anymore.
+func (s *roleService) DeleteRole(id uint) error {
+	role, err := s.roleRepo.GetByID(id)
+	if err != nil {
+		return errors.New("role not found")
+	}
+	if role.IsSystem {
+		return errors.New("system roles cannot be deleted")
+	}
+
+	users, err := s.roleRepo.CountUsers(role.Name)
+	if err != nil {
+		return err
+	}
+	if users > 0 {
+		return fmt.Errorf("role is still assigned to %d user(s)", users)
+	}
+
+	return s.roleRepo.Delete(role)
+}
+
+// GetRolePermissions returns the permissions granted to a role by name.
+func (s *roleService) GetRolePermissions(role string) ([]string, error) {
+	return s.roleRepo.GetPermissionNames(role)
+}
+
+// resolvePermissions loads the permissions with the given names, failing
+// on names the application does not know.
+func (s *roleService) resolvePermissions(names []string) ([]entities.Permission, error) {
+	unique := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+
+	permissions, err := s.permissionRepo.GetByNames(unique)
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) != len(unique) {
+		found := make(map[string]bool, len(permissions))
+		for _, permission := range permissions {
+			found[permission.Name] = true
+		}
+		for _, name := range unique {
+			if !found[name] {
+				return nil, fmt.Errorf("unknown permission: %s", name)
+			}
+		}
+	}
+
+	return permissions, nil
+}
+
+func roleToResponse(role *entities.Role) dto.RoleResponse {
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		permissions = append(permissions, permission.Name)
+	}
+
+	return dto.RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: permissions,
+	}
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type SeatMapService interface {
+	GetEventSeats(eventID uint) (*dto.SeatMapResponse, error)
+	UpdateEventSeatMap(eventID uint, req dto.UpdateSeatMapRequest) (*dto.SeatMapResponse, error)
+	GetVenueSeatMap(venueID uint) (*dto.SeatMapResponse, error)
+	UpdateVenueSeatMap(venueID uint, req dto.UpdateSeatMapRequest) (*dto.SeatMapResponse, error)
+}
+
+type seatMapService struct {
+	txManager repositories.TxManager
+	seatRepo  repositories.SeatRepository
+	eventRepo repositories.EventRepository
+	venueRepo repositories.VenueRepository
+}
+
+func NewSeatMapService(txManager repositories.TxManager, seatRepo repositories.SeatRepository, eventRepo repositories.EventRepository, venueRepo repositories.VenueRepository) SeatMapService {
+	return &seatMapService{
+		txManager: txManager,
+		seatRepo:  seatRepo,
+		eventRepo: eventRepo,
+		venueRepo: venueRepo,
+	}
+}
+
+// GetEventSeats returns the seat map of an event with the live status of
+// every seat.
+func (s *seatMapService) GetEventSeats(eventID uint) (*dto.SeatMapResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	sections, err := s.seatRepo.GetSectionsByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := seatMapToResponse(sections, true)
+	response.EventID = &eventID
+	return &response, nil
+}
+
+// UpdateEventSeatMap replaces the seat map of an event, either with the
+// given sections or with a copy of its venue's template. It is only allowed
+// before any ticket of the event is sold.
+func (s *seatMapService) UpdateEventSeatMap(eventID uint, req dto.UpdateSeatMapRequest) (*dto.SeatMapResponse, error) {
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		// Lock the event so no booking slips in while the map is replaced
+		event, err := s.eventRepo.WithTx(tx).GetByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		if isEventClosed(event) {
+			return errors.New("event is not available")
+		}
+		if event.SoldTickets > 0 {
+			return errors.New("seat map cannot be changed once tickets are sold")
+		}
+
+		var sections []entities.SeatSection
+		var total int
+		if req.CopyFromVenue {
+			if event.VenueID == nil {
+				return errors.New("event has no venue")
+			}
+			sections, total, err = s.copyVenueMap(tx, *event.VenueID)
+		} else {
+			sections, total, err = buildSeatSections(req.Sections)
+		}
+		if err != nil {
+			return err
+		}
+
+		if total > event.Capacity {
+			return fmt.Errorf("seat map has %d seats but the event capacity is %d", total, event.Capacity)
+		}
+
+		return s.seatRepo.WithTx(tx).ReplaceEventMap(event.ID, sections)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetEventSeats(eventID)
+}
+
+func (s *seatMapService) GetVenueSeatMap(venueID uint) (*dto.SeatMapResponse, error) {
+	if _, err := s.venueRepo.GetByID(venueID); err != nil {
+		return nil, errors.New("venue not found")
+	}
+
+	sections, err := s.seatRepo.GetSectionsByVenueID(venueID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := seatMapToResponse(sections, false)
+	response.VenueID = &venueID
+	return &response, nil
+}
+
+// UpdateVenueSeatMap replaces the seat map template of a venue. Events
+// already using a copy of the template keep their own seats.
+func (s *seatMapService) UpdateVenueSeatMap(venueID uint, req dto.UpdateSeatMapRequest) (*dto.SeatMapResponse, error) {
+	if req.CopyFromVenue {
+		return nil, errors.New("copy_from_venue only applies to events")
+	}
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		venue, err := s.venueRepo.WithTx(tx).GetByIDForUpdate(venueID)
+		if err != nil {
+			return errors.New("venue not found")
+		}
+
+		sections, total, err := buildSeatSections(req.Sections)
+		if err != nil {
+			return err
+		}
+
+		if total > venue.MaxCapacity {
+			return fmt.Errorf("seat map has %d seats but the venue capacity is %d", total, venue.MaxCapacity)
+		}
+
+		return s.seatRepo.WithTx(tx).ReplaceVenueMap(venue.ID, sections)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetVenueSeatMap(venueID)
+}
+
+// copyVenueMap builds fresh event sections from a venue's template.
+func (s *seatMapService) copyVenueMap(tx *gorm.DB, venueID uint) ([]entities.SeatSection, int, error) {
+	template, err := s.seatRepo.WithTx(tx).GetSectionsByVenueID(venueID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(template) == 0 {
+		return nil, 0, errors.New("venue has no seat map")
+	}
+
+	sections := make([]entities.SeatSection, 0, len(template))
+	total := 0
+	for _, section := range template {
+		copied := entities.SeatSection{Name: section.Name, Price: section.Price}
+		for _, seat := range section.Seats {
+			copied.Seats = append(copied.Seats, entities.Seat{
+				Row:    seat.Row,
+				Number: seat.Number,
+				Status: constants.SeatStatusAvailable,
+			})
+		}
+		total += len(copied.Seats)
+		sections = append(sections, copied)
+	}
+
+	return sections, total, nil
+}
+
+// buildSeatSections turns a seat map request into sections with their
+// seats, numbering the seats of every row from 1. It also returns the total
+// number of seats.
+func buildSeatSections(req []dto.SeatSectionRequest) ([]entities.SeatSection, int, error) {
+	if len(req) == 0 {
+		return nil, 0, errors.New("seat map needs at least one section")
+	}
+
+	sections := make([]entities.SeatSection, 0, len(req))
+	names := make(map[string]bool, len(req))
+	total := 0
+
+	for _, sectionReq := range req {
+		if names[sectionReq.Name] {
+			return nil, 0, fmt.Errorf("duplicate section %q", sectionReq.Name)
+		}
+		names[sectionReq.Name] = true
+
+		section := entities.SeatSection{Name: sectionReq.Name, Price: sectionReq.Price}
+		rows := make(map[string]bool, len(sectionReq.Rows))
+		for _, rowReq := range sectionReq.Rows {
+			if rows[rowReq.Label] {
+				return nil, 0, fmt.Errorf("duplicate row %q in section %q", rowReq.Label, sectionReq.Name)
+			}
+			rows[rowReq.Label] = true
+
+			for number := 1; number <= rowReq.Seats; number++ {
+				section.Seats = append(section.Seats, entities.Seat{
+					Row:    rowReq.Label,
+					Number: number,
+					Status: constants.SeatStatusAvailable,
+				})
+			}
+		}
+
+		total += len(section.Seats)
+		sections = append(sections, section)
+	}
+
+	return sections, total, nil
+}
+
+// seatMapToResponse builds a seat map response. Seat statuses are only
+// included for event seats, venue templates have none.
+func seatMapToResponse(sections []entities.SeatSection, withStatus bool) dto.SeatMapResponse {
+	response := dto.SeatMapResponse{
+		Sections: make([]dto.SeatSectionResponse, 0, len(sections)),
+	}
+
+	for _, section := range sections {
+		sectionResponse := dto.SeatSectionResponse{
+			ID:    section.ID,
+			Name:  section.Name,
+			Price: section.Price,
+			Seats: make([]dto.SeatResponse, 0, len(section.Seats)),
+		}
+
+		for _, seat := range section.Seats {
+			seatResponse := dto.SeatResponse{
+				ID:     seat.ID,
+				Row:    seat.Row,
+				Number: seat.Number,
+			}
+			if withStatus {
+				seatResponse.Status = seat.Status
+				if seat.Status == constants.SeatStatusAvailable {
+					response.AvailableSeats++
+				}
+			}
+			sectionResponse.Seats = append(sectionResponse.Seats, seatResponse)
+		}
+
+		response.TotalSeats += len(section.Seats)
+		response.Sections = append(response.Sections, sectionResponse)
+	}
+
+	return response
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/repositories"
+	"strconv"
+)
+
+type SettingService interface {
+	GetSettings() (*dto.SettingsResponse, error)
+	UpdateSettings(req dto.UpdateSettingsRequest) (*dto.SettingsResponse, error)
+	RequiresEmailVerification() (bool, error)
+}
+
+type settingService struct {
+	settingRepo repositories.SettingRepository
+	// requireEmailVerification applies until an admin changes the setting
+	requireEmailVerification bool
+}
+
+func NewSettingService(settingRepo repositories.SettingRepository, requireEmailVerification bool) SettingService {
+	return &settingService{
+		settingRepo:              settingRepo,
+		requireEmailVerification: requireEmailVerification,
+	}
+}
+
+func (s *settingService) GetSettings() (*dto.SettingsResponse, error) {
+	requireEmailVerification, err := s.RequiresEmailVerification()
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SettingsResponse{
+		RequireEmailVerification: requireEmailVerification,
+	}, nil
+}
+
+func (s *settingService) UpdateSettings(req dto.UpdateSettingsRequest) (*dto.SettingsResponse, error) {
+	if req.RequireEmailVerification != nil {
+		value := strconv.FormatBool(*req.RequireEmailVerification)
+		if err := s.settingRepo.Set(constants.SettingRequireEmailVerification, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetSettings()
+}
+
+// RequiresEmailVerification reports whether users must verify their email
+// address before they can book tickets.
+func (s *settingService) RequiresEmailVerification() (bool, error) {
+	setting, err := s.settingRepo.Get(constants.SettingRequireEmailVerification)
+	if err != nil {
+		return false, err
+	}
+	if setting == nil {
+		return s.requireEmailVerification, nil
+	}
+
+	required, err := strconv.ParseBool(setting.Value)
+	if err != nil {
+		return s.requireEmailVerification, nil
+	}
+	return required, nil
+}
@@ -1,32 +1,82 @@
 package services
 
 import (
+	"bytes"
+	"case_study_api/constants"
 	"case_study_api/dto"
 	"case_study_api/entities"
+	"case_study_api/payments"
 	"case_study_api/repositories"
 	"case_study_api/utils"
 	"errors"
 	"fmt"
+	"log"
+	"math"
+	"strings"
 	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
 )
 
 type TicketService interface {
 	GetUserTickets(userID uint) ([]dto.TicketResponse, error)
 	GetUserTicketsPaginated(userID uint, pagination utils.PaginationRequest) (*utils.PaginationResponse, error)
+	GetUserTicketsByCursor(userID uint, pagination utils.PaginationRequest) (*utils.CursorPaginationResponse, error)
 	GetByID(ticketID uint) (*dto.TicketResponse, error)
 	BookTicket(req dto.CreateTicketRequest, userID uint) (*dto.TicketResponse, error)
-	CancelTicket(ticketID uint, userID uint, req dto.CancelTicketRequest) error
+	CancelTicket(ticketID uint, userID uint, req dto.CancelTicketRequest) (*dto.RefundResponse, error)
+	HoldTickets(req dto.HoldTicketRequest, userID uint) (*dto.TicketHoldResponse, error)
+	ConfirmHold(token string, userID uint, req dto.ConfirmHoldRequest) (*dto.TicketResponse, error)
+	ReleaseHold(token string, userID uint) error
+	ReleaseExpiredHolds() (int, error)
+	GetTicketQRCode(ticketID uint, userID uint, isAdmin bool) ([]byte, error)
+	GenerateTicketPDF(ticketID uint, userID uint, isAdmin bool) ([]byte, string, error)
+	SettleOrder(orderID uint, status string) error
+	ProcessRefund(refundID uint) error
+	ExpireStaleOrders() (int, error)
 }
 
 type ticketService struct {
-	ticketRepo repositories.TicketRepository
-	eventRepo  repositories.EventRepository
+	txManager        repositories.TxManager
+	ticketRepo       repositories.TicketRepository
+	eventRepo        repositories.EventRepository
+	holdRepo         repositories.TicketHoldRepository
+	tierRepo         repositories.TicketTierRepository
+	waitlistRepo     repositories.WaitlistRepository
+	orderRepo        repositories.OrderRepository
+	paymentRepo      repositories.PaymentRepository
+	refundRepo       repositories.RefundRepository
+	refundPolicyRepo repositories.RefundPolicyRepository
+	promoRepo        repositories.PromoCodeRepository
+	seatRepo         repositories.SeatRepository
+	sessionRepo      repositories.SessionRepository
+	gateway          payments.Gateway
+	holdTTL          time.Duration
+	offerTTL         time.Duration
+	orderTTL         time.Duration
 }
 
-func NewTicketService(ticketRepo repositories.TicketRepository, eventRepo repositories.EventRepository) TicketService {
+func NewTicketService(txManager repositories.TxManager, ticketRepo repositories.TicketRepository, eventRepo repositories.EventRepository, holdRepo repositories.TicketHoldRepository, tierRepo repositories.TicketTierRepository, waitlistRepo repositories.WaitlistRepository, orderRepo repositories.OrderRepository, paymentRepo repositories.PaymentRepository, refundRepo repositories.RefundRepository, refundPolicyRepo repositories.RefundPolicyRepository, promoRepo repositories.PromoCodeRepository, seatRepo repositories.SeatRepository, sessionRepo repositories.SessionRepository, gateway payments.Gateway, holdTTL time.Duration, offerTTL time.Duration, orderTTL time.Duration) TicketService {
 	return &ticketService{
-		ticketRepo: ticketRepo,
-		eventRepo:  eventRepo,
+		txManager:        txManager,
+		ticketRepo:       ticketRepo,
+		eventRepo:        eventRepo,
+		holdRepo:         holdRepo,
+		tierRepo:         tierRepo,
+		waitlistRepo:     waitlistRepo,
+		orderRepo:        orderRepo,
+		paymentRepo:      paymentRepo,
+		refundRepo:       refundRepo,
+		refundPolicyRepo: refundPolicyRepo,
+		promoRepo:        promoRepo,
+		seatRepo:         seatRepo,
+		sessionRepo:      sessionRepo,
+		gateway:          gateway,
+		holdTTL:          holdTTL,
+		offerTTL:         offerTTL,
+		orderTTL:         orderTTL,
 	}
 }
 
@@ -59,6 +109,40 @@ func (s *ticketService) GetUserTicketsPaginated(userID uint, pagination utils.Pa
 	return &response, nil
 }
 
+// GetUserTicketsByCursor lists a user's tickets in ID order, one cursor
+// page at a time.
+func (s *ticketService) GetUserTicketsByCursor(userID uint, pagination utils.PaginationRequest) (*utils.CursorPaginationResponse, error) {
+	cursor, err := utils.DecodeCursor(pagination.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyset *repositories.Keyset
+	if cursor != nil {
+		keyset = &repositories.Keyset{ID: cursor.ID, Backward: cursor.Backward}
+	}
+
+	tickets, hasMore, err := s.ticketRepo.GetByUserIDCursor(userID, keyset, pagination.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketResponses := make([]dto.TicketResponse, 0, len(tickets))
+	for _, ticket := range tickets {
+		ticketResponses = append(ticketResponses, s.entityToResponse(ticket))
+	}
+
+	var first, last *utils.Cursor
+	if len(tickets) > 0 {
+		first = &utils.Cursor{ID: tickets[0].ID}
+		last = &utils.Cursor{ID: tickets[len(tickets)-1].ID}
+	}
+	next, prev := pageCursors(cursor, hasMore, first, last)
+
+	response := utils.BuildCursorResponse(ticketResponses, pagination.PageSize, next, prev)
+	return &response, nil
+}
+
 func (s *ticketService) GetByID(ticketID uint) (*dto.TicketResponse, error) {
 	ticket, err := s.ticketRepo.GetByID(ticketID)
 	if err != nil {
@@ -69,96 +153,1251 @@ func (s *ticketService) GetByID(ticketID uint) (*dto.TicketResponse, error) {
 	return &response, nil
 }
 
+// BookTicket reserves the tickets and opens an order for them. The ticket
+// stays pending until the payment gateway confirms the payment.
 func (s *ticketService) BookTicket(req dto.CreateTicketRequest, userID uint) (*dto.TicketResponse, error) {
-	// Get event details
-	event, err := s.eventRepo.GetByID(req.EventID)
+	var ticket entities.Ticket
+	var order *entities.Order
+	var payment *entities.Payment
+
+	// The event row stays locked until the ticket is written, so concurrent
+	// bookings for the same event are serialized and cannot oversell.
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		event, unitPrice, err := s.reserveTickets(tx, req.EventID, req.TierID, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		seats, err := s.selectSeats(tx, event.ID, req.SeatIDs, req.Quantity)
+		if err != nil {
+			return err
+		}
+
+		bookingCode, err := s.newBookingCode(tx)
+		if err != nil {
+			return err
+		}
+
+		// Create ticket
+		unitPrice = roundCents(unitPrice)
+		total := roundCents(float64(req.Quantity) * unitPrice)
+		if len(seats) > 0 {
+			unitPrice, total = seatedPrice(seats, unitPrice)
+		}
+		ticket = entities.Ticket{
+			UserID:       userID,
+			EventID:      event.ID,
+			TierID:       req.TierID,
+			Quantity:     req.Quantity,
+			UnitPrice:    unitPrice,
+			TotalPrice:   total,
+			BookingCode:  bookingCode,
+			PurchaseDate: time.Now(),
+			Status:       constants.TicketStatusPending,
+		}
+
+		promo, err := s.applyPromoCode(tx, req.PromoCode, &ticket)
+		if err != nil {
+			return err
+		}
+
+		if err := s.ticketRepo.WithTx(tx).Create(&ticket); err != nil {
+			return err
+		}
+		ticket.PromoCode = promo
+
+		if len(seats) > 0 {
+			if err := s.seatRepo.WithTx(tx).Book(event.ID, req.SeatIDs, ticket.ID); err != nil {
+				return seatClaimError(err)
+			}
+			ticket.Seats = seats
+		}
+
+		if err := s.redeemPromoCode(tx, &ticket); err != nil {
+			return err
+		}
+
+		order, payment, err = s.createOrder(tx, &ticket)
+		return err
+	})
 	if err != nil {
-		return nil, errors.New("event not found")
+		return nil, err
 	}
 
-	// Check event availability
-	if !event.IsActive || time.Now().After(event.EndDate) {
-		return nil, errors.New("event is not available")
+	return s.checkout(ticket, order, payment, req.PaymentScenario)
+}
+
+// CancelTicket cancels a booked ticket and refunds the share of its order
+// that the event's refund policy allows at this point in time.
+func (s *ticketService) CancelTicket(ticketID uint, userID uint, req dto.CancelTicketRequest) (*dto.RefundResponse, error) {
+	var refund entities.Refund
+	var order *entities.Order
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		ticketRepo := s.ticketRepo.WithTx(tx)
+
+		// Get ticket
+		ticket, err := ticketRepo.GetByIDForUpdate(ticketID)
+		if err != nil {
+			return err
+		}
+
+		// Check ownership
+		if ticket.UserID != userID {
+			return errors.New("unauthorized access")
+		}
+
+		// Check status
+		if ticket.Status != constants.TicketStatusBooked {
+			return errors.New("only booked tickets can be cancelled")
+		}
+		if ticket.CheckedInCount > 0 {
+			return errors.New("checked-in tickets cannot be cancelled")
+		}
+
+		event, err := s.eventRepo.WithTx(tx).GetByID(ticket.EventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		order, err = s.orderRepo.WithTx(tx).GetByTicketID(ticket.ID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+
+		// Update ticket status
+		now := time.Now()
+		ticket.Status = constants.TicketStatusCancelled
+		ticket.CancelledAt = &now
+		ticket.CancelReason = req.Reason
+
+		if err := ticketRepo.Update(ticket); err != nil {
+			return err
+		}
+
+		rules, err := s.refundPolicyRepo.WithTx(tx).GetByEventID(event.ID)
+		if err != nil {
+			return err
+		}
+
+		percentage := refundPercentage(rules, event.Date, now)
+		refund = entities.Refund{
+			TicketID:   ticket.ID,
+			OrderID:    order.ID,
+			UserID:     ticket.UserID,
+			Amount:     refundAmount(order.Amount, percentage),
+			Percentage: percentage,
+			Status:     constants.RefundStatusPending,
+			Reason:     req.Reason,
+		}
+		if refund.Amount == 0 {
+			refund.Status = constants.RefundStatusDeclined
+		}
+		if err := s.refundRepo.WithTx(tx).Create(&refund); err != nil {
+			return err
+		}
+
+		// Release the seats back to the event
+		if err := s.releaseTickets(tx, ticket.EventID, ticket.TierID, ticket.Quantity); err != nil {
+			return err
+		}
+		if err := s.seatRepo.WithTx(tx).ReleaseByTicketID(ticket.ID); err != nil {
+			return err
+		}
+		if err := releasePromoRedemption(s.promoRepo.WithTx(tx), ticket); err != nil {
+			return err
+		}
+		if err := releaseSessionPlaces(s.sessionRepo.WithTx(tx), ticketRepo, ticket.EventID, ticket.UserID); err != nil {
+			return err
+		}
+
+		return s.promoteWaitlist(tx, ticket.EventID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Check ticket availability
-	if event.Capacity-event.SoldTickets < req.Quantity {
-		return nil, fmt.Errorf("only %d tickets left", event.Capacity-event.SoldTickets)
+	if refund.Status == constants.RefundStatusPending {
+		s.processRefund(&refund, order)
+	}
+
+	response := refundToResponse(refund)
+	return &response, nil
+}
+
+// ProcessRefund pays out a refund that is still pending, for example one
+// created by an event cancellation.
+func (s *ticketService) ProcessRefund(refundID uint) error {
+	refund, err := s.refundRepo.GetByID(refundID)
+	if err != nil {
+		return errors.New("refund not found")
+	}
+	if refund.Status != constants.RefundStatusPending {
+		return nil
+	}
+
+	order, err := s.orderRepo.GetByID(refund.OrderID)
+	if err != nil {
+		return errors.New("order not found")
+	}
+
+	s.processRefund(refund, order)
+	return nil
+}
+
+// processRefund sends a pending refund to the gateway the order was paid
+// through. The ticket stays cancelled whatever the outcome; a failed refund
+// is recorded so it can be followed up.
+func (s *ticketService) processRefund(refund *entities.Refund, order *entities.Order) {
+	var paid *entities.Payment
+	for i := range order.Payments {
+		if order.Payments[i].Status == constants.PaymentStatusPaid {
+			paid = &order.Payments[i]
+			break
+		}
+	}
+
+	var result *payments.RefundResult
+	err := errors.New("order has no paid payment")
+	if paid != nil {
+		result, err = s.gateway.Refund(payments.RefundRequest{
+			Reference:  paid.Reference,
+			GatewayRef: paid.GatewayRef,
+			Amount:     refund.Amount,
+			Reason:     refund.Reason,
+		})
+	}
+	if err != nil {
+		log.Printf("Refund %d for order %d failed: %v", refund.ID, order.ID, err)
+		refund.Status = constants.RefundStatusFailed
+		if err := s.refundRepo.UpdateResult(refund.ID, refund.Status, "", nil); err != nil {
+			log.Printf("Failed to record refund %d failure: %v", refund.ID, err)
+		}
+		return
+	}
+
+	now := time.Now()
+	refund.Status = constants.RefundStatusProcessed
+	refund.GatewayRef = result.GatewayRef
+	refund.ProcessedAt = &now
+
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.refundRepo.WithTx(tx).UpdateResult(refund.ID, refund.Status, refund.GatewayRef, refund.ProcessedAt); err != nil {
+			return err
+		}
+
+		// A partial refund keeps the rest of the payment, the order must not
+		// read as fully refunded
+		status := constants.PaymentStatusRefunded
+		if refund.Percentage < 100 {
+			status = constants.PaymentStatusPartiallyRefunded
+		}
+
+		order.Status = status
+		if err := s.orderRepo.WithTx(tx).Update(order); err != nil {
+			return err
+		}
+		return s.paymentRepo.WithTx(tx).UpdateStatusByOrderID(order.ID, constants.PaymentStatusPaid, status, nil)
+	})
+	if err != nil {
+		log.Printf("Refund %d was paid out as %s but could not be recorded: %v", refund.ID, refund.GatewayRef, err)
+	}
+}
+
+// GetTicketQRCode renders a PNG QR code holding the signed ticket payload.
+// Only the ticket owner or an admin may fetch it.
+func (s *ticketService) GetTicketQRCode(ticketID uint, userID uint, isAdmin bool) ([]byte, error) {
+	ticket, err := s.ticketRepo.GetByID(ticketID)
+	if err != nil {
+		return nil, errors.New("ticket not found")
+	}
+
+	if ticket.UserID != userID && !isAdmin {
+		return nil, errors.New("unauthorized access")
+	}
+
+	if ticket.Status != constants.TicketStatusBooked {
+		return nil, fmt.Errorf("ticket is %s", ticket.Status)
+	}
+
+	return ticketQRCode(ticket)
+}
+
+// GenerateTicketPDF renders a printable e-ticket and returns it together with
+// a download file name. Only the ticket owner or an admin may download it.
+func (s *ticketService) GenerateTicketPDF(ticketID uint, userID uint, isAdmin bool) ([]byte, string, error) {
+	ticket, err := s.ticketRepo.GetByID(ticketID)
+	if err != nil {
+		return nil, "", errors.New("ticket not found")
+	}
+
+	if ticket.UserID != userID && !isAdmin {
+		return nil, "", errors.New("unauthorized access")
+	}
+
+	if ticket.Status != constants.TicketStatusBooked && ticket.Status != constants.TicketStatusUsed {
+		return nil, "", fmt.Errorf("ticket is %s", ticket.Status)
+	}
+
+	qrPNG, err := ticketQRCode(ticket)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pdfData, err := s.generateTicketPDF(ticket, qrPNG)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pdfData, fmt.Sprintf("e-ticket_%s.pdf", ticket.BookingCode), nil
+}
+
+func (s *ticketService) generateTicketPDF(ticket *entities.Ticket, qrPNG []byte) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+
+	// Title
+	pdf.SetFont("Arial", "B", 20)
+	pdf.Cell(0, 10, "Malaka Ticket - E-Ticket")
+	pdf.Ln(15)
+
+	// Event details
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, ticket.Event.Title)
+	pdf.Ln(10)
+
+	endDate := ticket.Event.EndDate
+	if endDate.IsZero() {
+		endDate = ticket.Event.Date
 	}
 
-	// Create ticket
-	total := float64(req.Quantity) * event.Price
-	ticket := entities.Ticket{
-		UserID:       userID,
-		EventID:      event.ID,
-		Quantity:     req.Quantity,
-		UnitPrice:    event.Price,
-		TotalPrice:   total,
-		BookingCode:  fmt.Sprintf("BK%v%v", userID, time.Now().UnixNano()%100000),
-		PurchaseDate: time.Now(),
-		Status:       "booked",
+	pdf.SetFont("Arial", "", 12)
+	pdf.Cell(0, 8, "Date: "+ticket.Event.Date.Format("Mon, 02 Jan 2006 15:04")+" - "+endDate.Format("Mon, 02 Jan 2006 15:04"))
+	pdf.Ln(6)
+	pdf.Cell(0, 8, "Location: "+ticket.Event.Location)
+	pdf.Ln(15)
+
+	// Ticket details
+	pdf.SetFont("Arial", "B", 16)
+	pdf.Cell(0, 10, "Ticket Details")
+	pdf.Ln(10)
+
+	tierName := "General Admission"
+	if ticket.Tier != nil {
+		tierName = ticket.Tier.Name
 	}
 
-	// Save ticket
-	if err := s.ticketRepo.Create(&ticket); err != nil {
+	pdf.SetFont("Arial", "", 12)
+	pdf.Cell(0, 8, "Ticket Holder: "+ticket.User.Name)
+	pdf.Ln(6)
+	pdf.Cell(0, 8, "Booking Code: "+ticket.BookingCode)
+	pdf.Ln(6)
+	pdf.Cell(0, 8, "Tier: "+tierName)
+	pdf.Ln(6)
+	pdf.Cell(0, 8, fmt.Sprintf("Quantity: %d", ticket.Quantity))
+	pdf.Ln(6)
+	if len(ticket.Seats) > 0 {
+		labels := make([]string, 0, len(ticket.Seats))
+		for _, seat := range ticket.Seats {
+			labels = append(labels, seatLabel(seat))
+		}
+		pdf.MultiCell(0, 8, "Seats: "+strings.Join(labels, ", "), "", "L", false)
+	}
+	pdf.Cell(0, 8, fmt.Sprintf("Total Price: $%.2f", ticket.TotalPrice))
+	pdf.Ln(6)
+	pdf.Cell(0, 8, "Purchased At: "+ticket.PurchaseDate.Format("2006-01-02 15:04:05"))
+	pdf.Ln(15)
+
+	// QR code
+	pdf.RegisterImageOptionsReader("ticket-qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrPNG))
+	pdf.ImageOptions("ticket-qr", pdf.GetX(), pdf.GetY(), 60, 60, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.Ln(65)
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.Cell(0, 6, "Show this QR code at the entrance. Do not share it with anyone.")
+
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
 		return nil, err
 	}
 
-	// Update event sold tickets
-	event.SoldTickets += req.Quantity
-	if err := s.eventRepo.Update(event); err != nil {
+	return buf.Bytes(), nil
+}
+
+// ticketQRCode renders the signed check-in payload of a ticket as a PNG.
+func ticketQRCode(ticket *entities.Ticket) ([]byte, error) {
+	return qrcode.Encode(utils.SignTicketPayload(ticket.ID, ticket.EventID), qrcode.Medium, 256)
+}
+
+func (s *ticketService) HoldTickets(req dto.HoldTicketRequest, userID uint) (*dto.TicketHoldResponse, error) {
+	var hold *entities.TicketHold
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		hold, err = s.placeHold(tx, userID, req.EventID, req.TierID, req.Quantity, req.SeatIDs, s.holdTTL)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	response := s.entityToResponse(ticket)
+	response := s.holdToResponse(*hold)
+	response.SeatIDs = req.SeatIDs
 	return &response, nil
 }
 
-func (s *ticketService) CancelTicket(ticketID uint, userID uint, req dto.CancelTicketRequest) error {
-	// Get ticket
-	ticket, err := s.ticketRepo.GetByID(ticketID)
+// placeHold reserves quantity for a user and records a hold that expires
+// after ttl. Held quantity counts towards sold_tickets until it is
+// confirmed, released, or swept. On events with reserved seating the given
+// seats are held as well.
+func (s *ticketService) placeHold(tx *gorm.DB, userID uint, eventID uint, tierID *uint, quantity int, seatIDs []uint, ttl time.Duration) (*entities.TicketHold, error) {
+	token, err := utils.GenerateSecureToken(24)
+	if err != nil {
+		return nil, errors.New("failed to generate hold token")
+	}
+
+	event, _, err := s.reserveTickets(tx, eventID, tierID, quantity)
 	if err != nil {
+		return nil, err
+	}
+
+	seats, err := s.selectSeats(tx, event.ID, seatIDs, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	hold := entities.TicketHold{
+		UserID:    userID,
+		EventID:   event.ID,
+		Quantity:  quantity,
+		TierID:    tierID,
+		Token:     token,
+		Status:    constants.HoldStatusActive,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err := s.holdRepo.WithTx(tx).Create(&hold); err != nil {
+		return nil, err
+	}
+
+	if len(seats) > 0 {
+		if err := s.seatRepo.WithTx(tx).Hold(event.ID, seatIDs, hold.ID); err != nil {
+			return nil, seatClaimError(err)
+		}
+	}
+	return &hold, nil
+}
+
+func (s *ticketService) ConfirmHold(token string, userID uint, req dto.ConfirmHoldRequest) (*dto.TicketResponse, error) {
+	var ticket entities.Ticket
+	var order *entities.Order
+	var payment *entities.Payment
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		holdRepo := s.holdRepo.WithTx(tx)
+
+		hold, err := holdRepo.GetByTokenForUpdate(token)
+		if err != nil {
+			return errors.New("hold not found")
+		}
+
+		if hold.UserID != userID {
+			return errors.New("unauthorized access")
+		}
+
+		if hold.Status != constants.HoldStatusActive {
+			return fmt.Errorf("hold is already %s", hold.Status)
+		}
+
+		if time.Now().After(hold.ExpiresAt) {
+			return errors.New("hold has expired")
+		}
+
+		event, err := s.eventRepo.WithTx(tx).GetByID(hold.EventID)
+		if err != nil || isEventClosed(event) {
+			return errors.New("event is not available")
+		}
+
+		unitPrice, err := s.unitPrice(tx, hold.EventID, hold.TierID)
+		if err != nil {
+			return err
+		}
+
+		seats, err := s.seatRepo.WithTx(tx).GetByHoldID(hold.ID)
+		if err != nil {
+			return err
+		}
+
+		bookingCode, err := s.newBookingCode(tx)
+		if err != nil {
+			return err
+		}
+
+		unitPrice = roundCents(unitPrice)
+		total := roundCents(float64(hold.Quantity) * unitPrice)
+		if len(seats) > 0 {
+			unitPrice, total = seatedPrice(seats, unitPrice)
+		}
+
+		// Capacity was already reserved when the hold was placed
+		ticket = entities.Ticket{
+			UserID:       userID,
+			EventID:      hold.EventID,
+			TierID:       hold.TierID,
+			Quantity:     hold.Quantity,
+			UnitPrice:    unitPrice,
+			TotalPrice:   total,
+			BookingCode:  bookingCode,
+			PurchaseDate: time.Now(),
+			Status:       constants.TicketStatusPending,
+		}
+		promo, err := s.applyPromoCode(tx, req.PromoCode, &ticket)
+		if err != nil {
+			return err
+		}
+		if err := s.ticketRepo.WithTx(tx).Create(&ticket); err != nil {
+			return err
+		}
+		ticket.PromoCode = promo
+		if len(seats) > 0 {
+			if err := s.seatRepo.WithTx(tx).BookHeld(hold.ID, ticket.ID); err != nil {
+				return err
+			}
+			ticket.Seats = seats
+		}
+		if err := s.redeemPromoCode(tx, &ticket); err != nil {
+			return err
+		}
+
+		order, payment, err = s.createOrder(tx, &ticket)
+		if err != nil {
+			return err
+		}
+
+		hold.Status = constants.HoldStatusConfirmed
+		hold.TicketID = &ticket.ID
+		if err := holdRepo.Update(hold); err != nil {
+			return err
+		}
+
+		return s.closeWaitlistOffer(tx, hold.ID, constants.WaitlistStatusFulfilled)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.checkout(ticket, order, payment, req.PaymentScenario)
+}
+
+// SettleOrder applies the final outcome of a payment. A paid order books its
+// ticket; a failed or expired one cancels the ticket and returns its seats.
+// Orders that are no longer pending are left untouched, so repeated gateway
+// callbacks are harmless. A payment captured after its order expired or
+// failed is refunded in full, the customer has no ticket for it.
+func (s *ticketService) SettleOrder(orderID uint, status string) error {
+	var lateRefund *entities.Refund
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		orderRepo := s.orderRepo.WithTx(tx)
+		ticketRepo := s.ticketRepo.WithTx(tx)
+
+		order, err := orderRepo.GetByIDForUpdate(orderID)
+		if err != nil {
+			return errors.New("order not found")
+		}
+
+		if order.Status != constants.PaymentStatusPending {
+			if status == constants.PaymentStatusPaid && (order.Status == constants.PaymentStatusExpired || order.Status == constants.PaymentStatusFailed) {
+				log.Printf("Payment confirmed for order %d which is already %s, refunding it", order.ID, order.Status)
+				lateRefund, err = s.refundLateCapture(tx, order)
+				return err
+			}
+			return nil
+		}
+
+		ticket, err := ticketRepo.GetByIDForUpdate(order.TicketID)
+		if err != nil {
+			return errors.New("ticket not found")
+		}
+
+		now := time.Now()
+		order.Status = status
+		switch status {
+		case constants.PaymentStatusPaid:
+			order.PaidAt = &now
+			ticket.Status = constants.TicketStatusBooked
+		case constants.PaymentStatusFailed, constants.PaymentStatusExpired:
+			ticket.Status = constants.TicketStatusCancelled
+			ticket.CancelledAt = &now
+			ticket.CancelReason = "payment " + status
+		default:
+			return fmt.Errorf("cannot settle order as %s", status)
+		}
+
+		if err := orderRepo.Update(order); err != nil {
+			return err
+		}
+		if err := s.paymentRepo.WithTx(tx).UpdateStatusByOrderID(order.ID, constants.PaymentStatusPending, status, order.PaidAt); err != nil {
+			return err
+		}
+		if err := ticketRepo.Update(ticket); err != nil {
+			return err
+		}
+
+		if ticket.Status != constants.TicketStatusCancelled {
+			return nil
+		}
+
+		// An unpaid booking gives its promo redemption back
+		if err := releasePromoRedemption(s.promoRepo.WithTx(tx), ticket); err != nil {
+			return err
+		}
+
+		if err := s.releaseTickets(tx, ticket.EventID, ticket.TierID, ticket.Quantity); err != nil {
+			return err
+		}
+		if err := s.seatRepo.WithTx(tx).ReleaseByTicketID(ticket.ID); err != nil {
+			return err
+		}
+		return s.promoteWaitlist(tx, ticket.EventID)
+	})
+	if err != nil || lateRefund == nil {
 		return err
 	}
 
-	// Check ownership
-	if ticket.UserID != userID {
-		return errors.New("unauthorized access")
+	return s.ProcessRefund(lateRefund.ID)
+}
+
+// refundLateCapture records the payment of an order that was settled
+// without it as paid and opens a full refund for it. The refund is paid out
+// by the caller once the transaction committed.
+func (s *ticketService) refundLateCapture(tx *gorm.DB, order *entities.Order) (*entities.Refund, error) {
+	refundRepo := s.refundRepo.WithTx(tx)
+
+	// A repeated callback finds the refund opened by the first one
+	existing, err := refundRepo.GetByTicketID(order.TicketID)
+	if err == nil {
+		if existing.Status == constants.RefundStatusPending {
+			return existing, nil
+		}
+		return nil, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.paymentRepo.WithTx(tx).UpdateStatusByOrderID(order.ID, order.Status, constants.PaymentStatusPaid, &now); err != nil {
+		return nil, err
+	}
+
+	refund := entities.Refund{
+		TicketID:   order.TicketID,
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		Amount:     order.Amount,
+		Percentage: 100,
+		Status:     constants.RefundStatusPending,
+		Reason:     "payment received after the order was " + order.Status,
+	}
+	if err := refundRepo.Create(&refund); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// ExpireStaleOrders expires every pending order whose payment deadline has
+// passed, releasing the tickets it reserved.
+func (s *ticketService) ExpireStaleOrders() (int, error) {
+	ids, err := s.orderRepo.GetExpiredPendingIDs(time.Now(), 500)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range ids {
+		if err := s.SettleOrder(id, constants.PaymentStatusExpired); err != nil {
+			return i, err
+		}
+	}
+
+	return len(ids), nil
+}
+
+// applyPromoCode checks code against a ticket that is about to be created,
+// claims one redemption of it and takes the discount off the total. An empty
+// code leaves the ticket untouched. Only PromoCodeID is set on the ticket;
+// the returned code is attached by the caller after the ticket is created so
+// GORM does not write the association back.
+func (s *ticketService) applyPromoCode(tx *gorm.DB, code string, ticket *entities.Ticket) (*entities.PromoCode, error) {
+	if code == "" {
+		return nil, nil
 	}
 
-	// Check status
-	if ticket.Status != "booked" {
-		return errors.New("only booked tickets can be cancelled")
+	promoRepo := s.promoRepo.WithTx(tx)
+
+	promo, err := promoRepo.GetByCodeForUpdate(normalizePromoCode(code))
+	if err != nil || !promo.IsActive {
+		return nil, errors.New("invalid promo code")
 	}
 
-	// Update ticket status
 	now := time.Now()
-	ticket.Status = "cancelled"
-	ticket.CancelledAt = &now
-	ticket.CancelReason = req.Reason
+	if promo.ValidFrom != nil && now.Before(*promo.ValidFrom) {
+		return nil, errors.New("promo code is not valid yet")
+	}
+	if promo.ValidUntil != nil && now.After(*promo.ValidUntil) {
+		return nil, errors.New("promo code has expired")
+	}
+	if promo.EventID != nil && *promo.EventID != ticket.EventID {
+		return nil, errors.New("promo code is not valid for this event")
+	}
+	if ticket.Quantity < promo.MinQuantity {
+		return nil, fmt.Errorf("promo code requires at least %d tickets", promo.MinQuantity)
+	}
+
+	if promo.PerUserLimit > 0 {
+		used, err := promoRepo.CountUserRedemptions(promo.ID, ticket.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= int64(promo.PerUserLimit) {
+			return nil, errors.New("promo code already used the maximum number of times")
+		}
+	}
+
+	if err := promoRepo.IncrementRedemptions(promo.ID); err != nil {
+		return nil, err
+	}
+
+	discount := promoDiscount(promo, ticket.TotalPrice)
+	ticket.PromoCodeID = &promo.ID
+	ticket.DiscountAmount = discount
+	ticket.TotalPrice = roundCents(ticket.TotalPrice - discount)
+	return promo, nil
+}
+
+// redeemPromoCode records the redemption claimed by applyPromoCode once the
+// ticket has an ID.
+func (s *ticketService) redeemPromoCode(tx *gorm.DB, ticket *entities.Ticket) error {
+	if ticket.PromoCodeID == nil {
+		return nil
+	}
+
+	redemption := entities.PromoRedemption{
+		PromoCodeID: *ticket.PromoCodeID,
+		UserID:      ticket.UserID,
+		TicketID:    ticket.ID,
+		Discount:    ticket.DiscountAmount,
+		Status:      constants.RedemptionStatusRedeemed,
+	}
+	return s.promoRepo.WithTx(tx).CreateRedemption(&redemption)
+}
+
+// releasePromoRedemption gives a cancelled ticket's promo code use back.
+// Callers pass a repository bound to the cancelling transaction.
+func releasePromoRedemption(promoRepo repositories.PromoCodeRepository, ticket *entities.Ticket) error {
+	if ticket.PromoCodeID == nil {
+		return nil
+	}
+
+	redemption, err := promoRepo.GetRedemptionByTicketID(ticket.ID)
+	if err != nil || redemption.Status != constants.RedemptionStatusRedeemed {
+		return nil
+	}
+
+	redemption.Status = constants.RedemptionStatusReleased
+	if err := promoRepo.UpdateRedemption(redemption); err != nil {
+		return err
+	}
+	return promoRepo.DecrementRedemptions(redemption.PromoCodeID)
+}
+
+// createOrder opens a pending order and payment for a freshly created ticket.
+func (s *ticketService) createOrder(tx *gorm.DB, ticket *entities.Ticket) (*entities.Order, *entities.Payment, error) {
+	reference, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return nil, nil, errors.New("failed to generate payment reference")
+	}
+
+	order := entities.Order{
+		UserID:    ticket.UserID,
+		TicketID:  ticket.ID,
+		Amount:    roundCents(ticket.TotalPrice),
+		Status:    constants.PaymentStatusPending,
+		ExpiresAt: time.Now().Add(s.orderTTL),
+	}
+	if err := s.orderRepo.WithTx(tx).Create(&order); err != nil {
+		return nil, nil, err
+	}
+
+	payment := entities.Payment{
+		OrderID:   order.ID,
+		Gateway:   s.gateway.Name(),
+		Reference: "PAY-" + reference,
+		Amount:    order.Amount,
+		Status:    constants.PaymentStatusPending,
+	}
+	if err := s.paymentRepo.WithTx(tx).Create(&payment); err != nil {
+		return nil, nil, err
+	}
+
+	return &order, &payment, nil
+}
+
+// checkout starts the gateway charge for a committed order and builds the
+// booking response. Free orders are settled right away without a charge.
+func (s *ticketService) checkout(ticket entities.Ticket, order *entities.Order, payment *entities.Payment, scenario string) (*dto.TicketResponse, error) {
+	if order.Amount == 0 {
+		if err := s.SettleOrder(order.ID, constants.PaymentStatusPaid); err != nil {
+			return nil, err
+		}
+		ticket.Status = constants.TicketStatusBooked
+		order.Status = constants.PaymentStatusPaid
+		payment.Status = constants.PaymentStatusPaid
+	} else {
+		charge, err := s.gateway.CreateCharge(payments.ChargeRequest{
+			Reference:   payment.Reference,
+			Amount:      payment.Amount,
+			Description: "Tickets " + ticket.BookingCode,
+			Scenario:    scenario,
+		})
+		if err != nil {
+			log.Printf("Failed to start payment %s: %v", payment.Reference, err)
+			if settleErr := s.SettleOrder(order.ID, constants.PaymentStatusFailed); settleErr != nil {
+				return nil, settleErr
+			}
+			return nil, errors.New("payment could not be started")
+		}
+
+		payment.GatewayRef = charge.GatewayRef
+		payment.PaymentURL = charge.PaymentURL
+		if err := s.paymentRepo.UpdateCharge(payment.ID, charge.GatewayRef, charge.PaymentURL); err != nil {
+			return nil, err
+		}
+	}
+
+	order.Payments = []entities.Payment{*payment}
+	response := s.entityToResponse(ticket)
+	orderResponse := orderToResponse(*order)
+	response.Order = &orderResponse
+	return &response, nil
+}
+
+func (s *ticketService) ReleaseHold(token string, userID uint) error {
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		hold, err := s.holdRepo.WithTx(tx).GetByTokenForUpdate(token)
+		if err != nil {
+			return errors.New("hold not found")
+		}
+
+		if hold.UserID != userID {
+			return errors.New("unauthorized access")
+		}
+
+		if hold.Status != constants.HoldStatusActive {
+			return fmt.Errorf("hold is already %s", hold.Status)
+		}
+
+		return s.releaseHold(tx, hold, constants.HoldStatusReleased, constants.WaitlistStatusDeclined)
+	})
+}
+
+// ReleaseExpiredHolds returns the quantity of every active hold past its TTL
+// to the event. Each hold is released in its own transaction so one failure
+// does not block the rest of the sweep; the failures are logged and returned
+// together once every hold was tried.
+func (s *ticketService) ReleaseExpiredHolds() (int, error) {
+	ids, err := s.holdRepo.GetExpiredActiveIDs(time.Now(), 500)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	var failures []error
+	for _, id := range ids {
+		err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+			hold, err := s.holdRepo.WithTx(tx).GetByIDForUpdate(id)
+			if err != nil {
+				return err
+			}
+
+			// The hold may have been confirmed since the IDs were read
+			if hold.Status != constants.HoldStatusActive {
+				return nil
+			}
+
+			if err := s.releaseHold(tx, hold, constants.HoldStatusExpired, constants.WaitlistStatusExpired); err != nil {
+				return err
+			}
+			released++
+			return nil
+		})
+		if err != nil {
+			log.Printf("Failed to release hold %d: %v", id, err)
+			failures = append(failures, fmt.Errorf("hold %d: %w", id, err))
+		}
+	}
+
+	return released, errors.Join(failures...)
+}
+
+// releaseHold marks a locked hold with the given final status and returns
+// its quantity to the event's availability. A waitlist offer the hold was
+// made for is closed with offerStatus, so declined offers stay apart from
+// offers that timed out.
+func (s *ticketService) releaseHold(tx *gorm.DB, hold *entities.TicketHold, status string, offerStatus string) error {
+	hold.Status = status
+	if err := s.holdRepo.WithTx(tx).Update(hold); err != nil {
+		return err
+	}
+
+	if err := s.releaseTickets(tx, hold.EventID, hold.TierID, hold.Quantity); err != nil {
+		return err
+	}
+	if err := s.seatRepo.WithTx(tx).ReleaseByHoldID(hold.ID); err != nil {
+		return err
+	}
+
+	if err := s.closeWaitlistOffer(tx, hold.ID, offerStatus); err != nil {
+		return err
+	}
+
+	return s.promoteWaitlist(tx, hold.EventID)
+}
+
+// promoteWaitlist offers freed capacity to the oldest waiting entries of an
+// event that still fit, placing a time-limited hold for each of them.
+func (s *ticketService) promoteWaitlist(tx *gorm.DB, eventID uint) error {
+	waitlistRepo := s.waitlistRepo.WithTx(tx)
+
+	// Lock the event first so concurrent promotions are serialized
+	event, err := s.eventRepo.WithTx(tx).GetByIDForUpdate(eventID)
+	if err != nil {
+		return err
+	}
+
+	entries, err := waitlistRepo.GetWaitingByEventID(eventID)
+	if err != nil {
+		return err
+	}
+
+	if isEventClosed(event) {
+		return nil
+	}
+
+	seatCount, err := s.seatRepo.WithTx(tx).CountByEventID(eventID)
+	if err != nil {
+		return err
+	}
+
+	available := event.Capacity - event.SoldTickets
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Quantity > available {
+			continue
+		}
+
+		// A savepoint keeps a failed reservation (e.g. a sold-out tier)
+		// from leaking partial updates into the outer transaction
+		var hold *entities.TicketHold
+		err := tx.Transaction(func(sp *gorm.DB) error {
+			// Waitlisted users get the best free seats on seated events
+			var seatIDs []uint
+			if seatCount > 0 {
+				var err error
+				seatIDs, err = s.seatRepo.WithTx(sp).GetAvailableIDs(entry.EventID, entry.Quantity)
+				if err != nil {
+					return err
+				}
+			}
+
+			var err error
+			hold, err = s.placeHold(sp, entry.UserID, entry.EventID, entry.TierID, entry.Quantity, seatIDs, s.offerTTL)
+			return err
+		})
+		if err != nil {
+			continue
+		}
 
-	// Update event sold tickets
-	event, err := s.eventRepo.GetByID(ticket.EventID)
-	if err == nil && event.SoldTickets >= ticket.Quantity {
-		event.SoldTickets -= ticket.Quantity
-		s.eventRepo.Update(event)
+		now := time.Now()
+		entry.Status = constants.WaitlistStatusOffered
+		entry.HoldID = &hold.ID
+		entry.OfferedAt = &now
+		if err := waitlistRepo.Update(entry); err != nil {
+			return err
+		}
+
+		available -= entry.Quantity
+	}
+
+	return nil
+}
+
+// closeWaitlistOffer settles the waitlist entry that a hold was offered to,
+// if any.
+func (s *ticketService) closeWaitlistOffer(tx *gorm.DB, holdID uint, status string) error {
+	waitlistRepo := s.waitlistRepo.WithTx(tx)
+
+	entry, err := waitlistRepo.GetByHoldID(holdID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	entry.Status = status
+	return waitlistRepo.Update(entry)
+}
+
+// reserveTickets locks the event (and tier, when given), validates that the
+// requested quantity is on sale and atomically adds it to the sold counters.
+// It returns the locked event and the unit price to charge.
+func (s *ticketService) reserveTickets(tx *gorm.DB, eventID uint, tierID *uint, quantity int) (*entities.Event, float64, error) {
+	eventRepo := s.eventRepo.WithTx(tx)
+	tierRepo := s.tierRepo.WithTx(tx)
+
+	// Get event details
+	event, err := eventRepo.GetByIDForUpdate(eventID)
+	if err != nil {
+		return nil, 0, errors.New("event not found")
+	}
+
+	// Check event availability
+	if !event.IsActive || isEventClosed(event) || time.Now().After(event.EndDate) {
+		return nil, 0, errors.New("event is not available")
+	}
+
+	unitPrice := event.Price
+	if tierID == nil {
+		tierCount, err := tierRepo.CountByEventID(event.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if tierCount > 0 {
+			return nil, 0, errors.New("tier_id is required for this event")
+		}
+	} else {
+		tier, err := tierRepo.GetByIDForUpdate(*tierID)
+		if err != nil || tier.EventID != event.ID {
+			return nil, 0, errors.New("tier not found")
+		}
+
+		now := time.Now()
+		if (tier.SalesStart != nil && now.Before(*tier.SalesStart)) || (tier.SalesEnd != nil && now.After(*tier.SalesEnd)) {
+			return nil, 0, fmt.Errorf("tier %s is not on sale", tier.Name)
+		}
+
+		if err := tierRepo.IncrementSold(tier.ID, quantity); err != nil {
+			if errors.Is(err, repositories.ErrInsufficientTierCapacity) {
+				return nil, 0, fmt.Errorf("only %d %s tickets left", tier.Capacity-tier.Sold, tier.Name)
+			}
+			return nil, 0, err
+		}
+		unitPrice = tier.Price
+	}
+
+	// Check ticket availability
+	if event.Capacity-event.SoldTickets < quantity {
+		return nil, 0, fmt.Errorf("only %d tickets left", event.Capacity-event.SoldTickets)
+	}
+
+	// Reserve the seats with a conditional update as a second guard
+	if err := eventRepo.IncrementSoldTickets(event.ID, quantity); err != nil {
+		if errors.Is(err, repositories.ErrInsufficientCapacity) {
+			return nil, 0, fmt.Errorf("only %d tickets left", event.Capacity-event.SoldTickets)
+		}
+		return nil, 0, err
+	}
+
+	return event, unitPrice, nil
+}
+
+// selectSeats checks the seats picked for a booking of quantity tickets.
+// Events with a seat map need exactly one available seat per ticket; other
+// events take no seats. The seats are claimed by the caller.
+func (s *ticketService) selectSeats(tx *gorm.DB, eventID uint, seatIDs []uint, quantity int) ([]entities.Seat, error) {
+	seatRepo := s.seatRepo.WithTx(tx)
+
+	seatCount, err := seatRepo.CountByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if seatCount == 0 {
+		if len(seatIDs) > 0 {
+			return nil, errors.New("event has no reserved seating")
+		}
+		return nil, nil
+	}
+
+	if len(seatIDs) == 0 {
+		return nil, errors.New("seat_ids is required for this event")
+	}
+	if len(seatIDs) != quantity {
+		return nil, errors.New("select exactly one seat per ticket")
+	}
+
+	picked := make(map[uint]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		if picked[id] {
+			return nil, errors.New("the same seat was selected twice")
+		}
+		picked[id] = true
+	}
+
+	seats, err := seatRepo.GetByIDs(eventID, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(seats) != len(seatIDs) {
+		return nil, errors.New("seat not found")
+	}
+
+	for _, seat := range seats {
+		if seat.Status != constants.SeatStatusAvailable {
+			return nil, fmt.Errorf("seat %s is no longer available", seatLabel(seat))
+		}
 	}
 
-	return s.ticketRepo.Update(ticket)
+	return seats, nil
+}
+
+// seatClaimError turns a failed conditional seat update into a message for
+// the user.
+func seatClaimError(err error) error {
+	if errors.Is(err, repositories.ErrSeatsUnavailable) {
+		return errors.New("one or more seats are no longer available")
+	}
+	return err
+}
+
+// seatedPrice returns the unit and total price of a booking for seats.
+// basePrice is the event price, or the tier price when a tier was picked.
+// A section price takes precedence over both: seats in a priced section
+// cost the section price whatever the tier, the rest cost basePrice. When
+// the prices differ the unit price is their average.
+func seatedPrice(seats []entities.Seat, basePrice float64) (float64, float64) {
+	total := 0.0
+	for _, seat := range seats {
+		if seat.Section.Price != nil {
+			total += *seat.Section.Price
+		} else {
+			total += basePrice
+		}
+	}
+
+	total = roundCents(total)
+	return roundCents(total / float64(len(seats))), total
+}
+
+// roundCents rounds an amount of money to whole cents. Prices are rounded
+// before they are stored so tickets, orders and payments agree exactly.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// toCents converts an amount of money to integer cents for exact
+// comparisons.
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// seatLabel formats a seat the way it is printed on tickets, e.g. "VIP A-12".
+func seatLabel(seat entities.Seat) string {
+	return fmt.Sprintf("%s %s-%d", seat.Section.Name, seat.Row, seat.Number)
+}
+
+// releaseTickets returns quantity to the event and tier availability.
+func (s *ticketService) releaseTickets(tx *gorm.DB, eventID uint, tierID *uint, quantity int) error {
+	if tierID != nil {
+		if err := s.tierRepo.WithTx(tx).DecrementSold(*tierID, quantity); err != nil {
+			return err
+		}
+	}
+
+	return s.eventRepo.WithTx(tx).DecrementSoldTickets(eventID, quantity)
+}
+
+// unitPrice resolves the current price of a ticket for an event or tier.
+func (s *ticketService) unitPrice(tx *gorm.DB, eventID uint, tierID *uint) (float64, error) {
+	if tierID != nil {
+		tier, err := s.tierRepo.WithTx(tx).GetByID(*tierID)
+		if err != nil {
+			return 0, errors.New("tier not found")
+		}
+		return tier.Price, nil
+	}
+
+	event, err := s.eventRepo.WithTx(tx).GetByID(eventID)
+	if err != nil {
+		return 0, errors.New("event not found")
+	}
+	return event.Price, nil
+}
+
+func (s *ticketService) holdToResponse(hold entities.TicketHold) dto.TicketHoldResponse {
+	return dto.TicketHoldResponse{
+		Token:     hold.Token,
+		EventID:   hold.EventID,
+		Quantity:  hold.Quantity,
+		Status:    hold.Status,
+		ExpiresAt: hold.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+		TierID:    hold.TierID,
+		TicketID:  hold.TicketID,
+	}
+}
+
+// newBookingCode generates a random booking code that is not used by any
+// existing ticket. The unique index on booking_code remains the final guard.
+func (s *ticketService) newBookingCode(tx *gorm.DB) (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := utils.GenerateBookingCode()
+		if err != nil {
+			return "", errors.New("failed to generate booking code")
+		}
+
+		exists, err := s.ticketRepo.WithTx(tx).ExistsByBookingCode(code)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+
+	return "", errors.New("failed to generate a unique booking code")
 }
 
 func (s *ticketService) entityToResponse(ticket entities.Ticket) dto.TicketResponse {
+	return ticketToResponse(ticket)
+}
+
+// ticketToResponse is shared with the services that embed tickets in their
+// own responses, such as orders.
+func ticketToResponse(ticket entities.Ticket) dto.TicketResponse {
 	response := dto.TicketResponse{
-		ID:           ticket.ID,
-		UserID:       ticket.UserID,
-		EventID:      ticket.EventID,
-		Quantity:     ticket.Quantity,
-		UnitPrice:    ticket.UnitPrice,
-		TotalPrice:   ticket.TotalPrice,
-		Status:       ticket.Status,
-		BookingCode:  ticket.BookingCode,
-		PurchaseDate: ticket.PurchaseDate.Format("2006-01-02T15:04:05Z"),
-		CancelReason: ticket.CancelReason,
+		ID:             ticket.ID,
+		UserID:         ticket.UserID,
+		EventID:        ticket.EventID,
+		TierID:         ticket.TierID,
+		Quantity:       ticket.Quantity,
+		UnitPrice:      ticket.UnitPrice,
+		DiscountAmount: ticket.DiscountAmount,
+		TotalPrice:     ticket.TotalPrice,
+		Status:         ticket.Status,
+		BookingCode:    ticket.BookingCode,
+		PurchaseDate:   ticket.PurchaseDate.Format("2006-01-02T15:04:05Z"),
+		CancelReason:   ticket.CancelReason,
+		CheckedInCount: ticket.CheckedInCount,
+	}
+
+	if ticket.CheckedInAt != nil {
+		checkedInAt := ticket.CheckedInAt.Format("2006-01-02T15:04:05Z")
+		response.CheckedInAt = &checkedInAt
+	}
+
+	if ticket.Tier != nil {
+		response.TierName = ticket.Tier.Name
 	}
 
 	if ticket.CancelledAt != nil {
@@ -166,6 +1405,24 @@ func (s *ticketService) entityToResponse(ticket entities.Ticket) dto.TicketRespo
 		response.CancelledAt = &cancelledAt
 	}
 
+	if ticket.PromoCode != nil {
+		response.PromoCode = ticket.PromoCode.Code
+	}
+
+	for _, seat := range ticket.Seats {
+		response.Seats = append(response.Seats, dto.TicketSeatResponse{
+			ID:      seat.ID,
+			Section: seat.Section.Name,
+			Row:     seat.Row,
+			Number:  seat.Number,
+		})
+	}
+
+	if ticket.Refund != nil {
+		refundResponse := refundToResponse(*ticket.Refund)
+		response.Refund = &refundResponse
+	}
+
 	// Include event details if available
 	if ticket.Event.ID != 0 {
 		eventResponse := dto.EventResponse{
@@ -188,3 +1445,29 @@ func (s *ticketService) entityToResponse(ticket entities.Ticket) dto.TicketRespo
 
 	return response
 }
+
+func refundToResponse(refund entities.Refund) dto.RefundResponse {
+	response := dto.RefundResponse{
+		ID:         refund.ID,
+		TicketID:   refund.TicketID,
+		OrderID:    refund.OrderID,
+		Amount:     refund.Amount,
+		Percentage: refund.Percentage,
+		Status:     refund.Status,
+		Reason:     refund.Reason,
+		CreatedAt:  refund.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if refund.ProcessedAt != nil {
+		processedAt := refund.ProcessedAt.Format("2006-01-02T15:04:05Z")
+		response.ProcessedAt = &processedAt
+	}
+
+	return response
+}
+
+// isEventClosed reports whether an event no longer takes bookings because
+// it is over or was called off.
+func isEventClosed(event *entities.Event) bool {
+	return event.Status == constants.EventStatusCancelled || event.Status == constants.EventStatusCompleted
+}
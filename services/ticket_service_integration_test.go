@@ -0,0 +1,193 @@
+//go:build integration
+
+package services_test
+
+import (
+	"case_study_api/config"
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/payments"
+	"case_study_api/repositories"
+	"case_study_api/services"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Run with:
+//
+//	TEST_DB_DSN="user:pass@tcp(localhost:3306)/ticket_test?parseTime=True&loc=Local" go test -tags integration ./services/
+//
+// The database is migrated but not reset; the test removes the rows it creates.
+
+// pendingGateway accepts every charge and never calls back, so booked
+// tickets stay pending and keep their capacity.
+type pendingGateway struct{}
+
+func (pendingGateway) Name() string { return "test" }
+
+func (pendingGateway) CreateCharge(req payments.ChargeRequest) (*payments.Charge, error) {
+	return &payments.Charge{GatewayRef: "test-" + req.Reference, Status: payments.StatusPending}, nil
+}
+
+func (pendingGateway) Refund(req payments.RefundRequest) (*payments.RefundResult, error) {
+	return &payments.RefundResult{GatewayRef: "refund-" + req.GatewayRef}, nil
+}
+
+func (pendingGateway) ParseCallback(payload []byte, signature string) (*payments.CallbackEvent, error) {
+	return nil, payments.ErrInvalidSignature
+}
+
+func (pendingGateway) SignatureHeader() string { return "X-Test-Signature" }
+
+func openTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if err := config.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("pool: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(50)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func newTestTicketService(db *gorm.DB) services.TicketService {
+	return services.NewTicketService(
+		repositories.NewTxManager(db),
+		repositories.NewTicketRepository(db),
+		repositories.NewEventRepository(db),
+		repositories.NewTicketHoldRepository(db),
+		repositories.NewTicketTierRepository(db),
+		repositories.NewWaitlistRepository(db),
+		repositories.NewOrderRepository(db),
+		repositories.NewPaymentRepository(db),
+		repositories.NewRefundRepository(db),
+		repositories.NewRefundPolicyRepository(db),
+		repositories.NewPromoCodeRepository(db),
+		repositories.NewSeatRepository(db),
+		repositories.NewSessionRepository(db),
+		pendingGateway{},
+		10*time.Minute,
+		10*time.Minute,
+		15*time.Minute,
+	)
+}
+
+func TestBookTicketConcurrentNeverOversells(t *testing.T) {
+	const capacity = 5
+	const attempts = 300
+
+	db := openTestDB(t)
+	ticketService := newTestTicketService(db)
+
+	suffix := time.Now().UnixNano()
+	user := entities.User{
+		Name:     "Concurrency Test",
+		Email:    fmt.Sprintf("concurrency-%d@test.local", suffix),
+		Password: "x",
+		Role:     constants.UserRoleUser,
+	}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	start := time.Now().Add(48 * time.Hour)
+	event := entities.Event{
+		Title:     fmt.Sprintf("Concurrency Test %d", suffix),
+		Status:    constants.EventStatusUpcoming,
+		Date:      start,
+		EndDate:   start.Add(2 * time.Hour),
+		Capacity:  capacity,
+		Price:     25,
+		CreatedBy: user.ID,
+		IsActive:  true,
+	}
+	if err := db.Create(&event).Error; err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+
+	t.Cleanup(func() {
+		orders := db.Unscoped().Model(&entities.Order{}).Select("id").Where("user_id = ?", user.ID)
+		db.Unscoped().Where("order_id IN (?)", orders).Delete(&entities.Payment{})
+		db.Unscoped().Where("user_id = ?", user.ID).Delete(&entities.Order{})
+		db.Unscoped().Where("event_id = ?", event.ID).Delete(&entities.Ticket{})
+		db.Unscoped().Delete(&event)
+		db.Unscoped().Delete(&user)
+	})
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	booked := 0
+	unexpected := []error{}
+
+	ready := make(chan struct{})
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ready
+
+			_, err := ticketService.BookTicket(dto.CreateTicketRequest{EventID: event.ID, Quantity: 1}, user.ID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				booked++
+			case isSoldOut(err):
+			default:
+				unexpected = append(unexpected, err)
+			}
+		}()
+	}
+	close(ready)
+	wg.Wait()
+
+	for _, err := range unexpected {
+		t.Errorf("unexpected booking error: %v", err)
+	}
+
+	var stored entities.Event
+	if err := db.First(&stored, event.ID).Error; err != nil {
+		t.Fatalf("reload event: %v", err)
+	}
+	if stored.SoldTickets > stored.Capacity {
+		t.Fatalf("sold_tickets %d exceeds capacity %d", stored.SoldTickets, stored.Capacity)
+	}
+	if booked != capacity {
+		t.Errorf("booked %d tickets, want %d", booked, capacity)
+	}
+	if stored.SoldTickets != booked {
+		t.Errorf("sold_tickets is %d but %d bookings succeeded", stored.SoldTickets, booked)
+	}
+
+	var tickets int64
+	db.Model(&entities.Ticket{}).Where("event_id = ?", event.ID).Count(&tickets)
+	if int(tickets) != booked {
+		t.Errorf("%d ticket rows for %d successful bookings", tickets, booked)
+	}
+}
+
+func isSoldOut(err error) bool {
+	return strings.HasPrefix(err.Error(), "only ") && strings.HasSuffix(err.Error(), " tickets left")
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TicketTierService interface {
+	GetByEventID(eventID uint) ([]dto.TicketTierResponse, error)
+	Create(eventID uint, req dto.CreateTicketTierRequest) (*dto.TicketTierResponse, error)
+	Update(eventID uint, tierID uint, req dto.UpdateTicketTierRequest) (*dto.TicketTierResponse, error)
+	Delete(eventID uint, tierID uint) error
+}
+
+type ticketTierService struct {
+	txManager repositories.TxManager
+	tierRepo  repositories.TicketTierRepository
+	eventRepo repositories.EventRepository
+}
+
+func NewTicketTierService(txManager repositories.TxManager, tierRepo repositories.TicketTierRepository, eventRepo repositories.EventRepository) TicketTierService {
+	return &ticketTierService{
+		txManager: txManager,
+		tierRepo:  tierRepo,
+		eventRepo: eventRepo,
+	}
+}
+
+func (s *ticketTierService) GetByEventID(eventID uint) ([]dto.TicketTierResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	tiers, err := s.tierRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	tierResponses := make([]dto.TicketTierResponse, 0, len(tiers))
+	for _, tier := range tiers {
+		tierResponses = append(tierResponses, s.entityToResponse(tier))
+	}
+
+	return tierResponses, nil
+}
+
+func (s *ticketTierService) Create(eventID uint, req dto.CreateTicketTierRequest) (*dto.TicketTierResponse, error) {
+	salesStart, salesEnd, err := parseSalesWindow(req.SalesStart, req.SalesEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := entities.TicketTier{
+		EventID:    eventID,
+		Name:       req.Name,
+		Price:      req.Price,
+		Capacity:   req.Capacity,
+		SalesStart: salesStart,
+		SalesEnd:   salesEnd,
+	}
+
+	// The event row stays locked until the tier is written, so concurrent
+	// tier changes cannot allocate more than the event capacity together.
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		event, err := s.eventRepo.WithTx(tx).GetByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		tierRepo := s.tierRepo.WithTx(tx)
+		if err := checkEventCapacity(tierRepo, event, 0, req.Capacity); err != nil {
+			return err
+		}
+		return tierRepo.Create(&tier)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.entityToResponse(tier)
+	return &response, nil
+}
+
+func (s *ticketTierService) Update(eventID uint, tierID uint, req dto.UpdateTicketTierRequest) (*dto.TicketTierResponse, error) {
+	var tier *entities.TicketTier
+
+	// Same locking order as Create and booking: the event first, then the tier
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		event, err := s.eventRepo.WithTx(tx).GetByIDForUpdate(eventID)
+		if err != nil {
+			return errors.New("event not found")
+		}
+
+		tierRepo := s.tierRepo.WithTx(tx)
+		tier, err = tierRepo.GetByIDForUpdate(tierID)
+		if err != nil || tier.EventID != eventID {
+			return errors.New("tier not found")
+		}
+
+		// Update fields if provided
+		if req.Name != "" {
+			tier.Name = req.Name
+		}
+		if req.Price != nil {
+			tier.Price = *req.Price
+		}
+		if req.Capacity > 0 {
+			if req.Capacity < tier.Sold {
+				return fmt.Errorf("capacity cannot be lower than the %d tickets already sold", tier.Sold)
+			}
+			if err := checkEventCapacity(tierRepo, event, tier.ID, req.Capacity); err != nil {
+				return err
+			}
+			tier.Capacity = req.Capacity
+		}
+
+		salesStart, salesEnd := req.SalesStart, req.SalesEnd
+		if salesStart == "" && tier.SalesStart != nil {
+			salesStart = tier.SalesStart.Format("2006-01-02T15:04:05Z")
+		}
+		if salesEnd == "" && tier.SalesEnd != nil {
+			salesEnd = tier.SalesEnd.Format("2006-01-02T15:04:05Z")
+		}
+		tier.SalesStart, tier.SalesEnd, err = parseSalesWindow(salesStart, salesEnd)
+		if err != nil {
+			return err
+		}
+
+		return tierRepo.Update(tier)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.entityToResponse(*tier)
+	return &response, nil
+}
+
+func (s *ticketTierService) Delete(eventID uint, tierID uint) error {
+	tier, err := s.getEventTier(eventID, tierID)
+	if err != nil {
+		return err
+	}
+
+	if tier.Sold > 0 {
+		return errors.New("cannot delete tier with sold tickets")
+	}
+
+	// Past tickets keep pointing to the tier, end its sales instead
+	tickets, err := s.tierRepo.CountTickets(tier.ID)
+	if err != nil {
+		return err
+	}
+	if tickets > 0 {
+		return errors.New("cannot delete tier with past tickets, end its sales instead")
+	}
+
+	return s.tierRepo.Delete(tier)
+}
+
+func (s *ticketTierService) getEventTier(eventID uint, tierID uint) (*entities.TicketTier, error) {
+	tier, err := s.tierRepo.GetByID(tierID)
+	if err != nil || tier.EventID != eventID {
+		return nil, errors.New("tier not found")
+	}
+	return tier, nil
+}
+
+// checkEventCapacity makes sure the tiers of an event never promise more
+// seats than the event itself has. Callers hold the event row lock and pass
+// a repository bound to the same transaction.
+func checkEventCapacity(tierRepo repositories.TicketTierRepository, event *entities.Event, excludeTierID uint, capacity int) error {
+	allocated, err := tierRepo.SumCapacityByEventID(event.ID, excludeTierID)
+	if err != nil {
+		return err
+	}
+
+	if allocated+capacity > event.Capacity {
+		return fmt.Errorf("tier capacity exceeds event capacity, only %d seats left to allocate", event.Capacity-allocated)
+	}
+	return nil
+}
+
+func parseSalesWindow(start, end string) (*time.Time, *time.Time, error) {
+	var salesStart, salesEnd *time.Time
+
+	if start != "" {
+		parsed, err := time.Parse("2006-01-02T15:04:05Z", start)
+		if err != nil {
+			return nil, nil, errors.New("invalid sales start format")
+		}
+		salesStart = &parsed
+	}
+
+	if end != "" {
+		parsed, err := time.Parse("2006-01-02T15:04:05Z", end)
+		if err != nil {
+			return nil, nil, errors.New("invalid sales end format")
+		}
+		salesEnd = &parsed
+	}
+
+	if salesStart != nil && salesEnd != nil && salesEnd.Before(*salesStart) {
+		return nil, nil, errors.New("sales end must be after sales start")
+	}
+
+	return salesStart, salesEnd, nil
+}
+
+func (s *ticketTierService) entityToResponse(tier entities.TicketTier) dto.TicketTierResponse {
+	response := dto.TicketTierResponse{
+		ID:        tier.ID,
+		EventID:   tier.EventID,
+		Name:      tier.Name,
+		Price:     tier.Price,
+		Capacity:  tier.Capacity,
+		Sold:      tier.Sold,
+		Available: tier.Capacity - tier.Sold,
+	}
+
+	if tier.SalesStart != nil {
+		salesStart := tier.SalesStart.Format("2006-01-02T15:04:05Z")
+		response.SalesStart = &salesStart
+	}
+	if tier.SalesEnd != nil {
+		salesEnd := tier.SalesEnd.Format("2006-01-02T15:04:05Z")
+		response.SalesEnd = &salesEnd
+	}
+
+	return response
+}
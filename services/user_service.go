@@ -0,0 +1,233 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"case_study_api/utils"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserService is the admin side of user management.
+type UserService interface {
+	GetUsers(query dto.UserListQuery, pagination utils.PaginationRequest) (*utils.PaginationResponse, error)
+	GetUserDetail(userID uint) (*dto.AdminUserDetailResponse, error)
+	UpdateRole(adminID uint, userID uint, req dto.UpdateUserRoleRequest) (*dto.AdminUserResponse, error)
+	Suspend(adminID uint, userID uint, req dto.SuspendUserRequest) (*dto.AdminUserResponse, error)
+	Unsuspend(userID uint) (*dto.AdminUserResponse, error)
+}
+
+type userService struct {
+	txManager     repositories.TxManager
+	userRepo      repositories.UserRepository
+	refreshRepo   repositories.RefreshTokenRepository
+	roleRepo      repositories.RoleRepository
+	reportRepo    repositories.ReportRepository
+	ticketService TicketService
+}
+
+func NewUserService(txManager repositories.TxManager, userRepo repositories.UserRepository, refreshRepo repositories.RefreshTokenRepository, roleRepo repositories.RoleRepository, reportRepo repositories.ReportRepository, ticketService TicketService) UserService {
+	return &userService{
+		txManager:     txManager,
+		userRepo:      userRepo,
+		refreshRepo:   refreshRepo,
+		roleRepo:      roleRepo,
+		reportRepo:    reportRepo,
+		ticketService: ticketService,
+	}
+}
+
+func (s *userService) GetUsers(query dto.UserListQuery, pagination utils.PaginationRequest) (*utils.PaginationResponse, error) {
+	filter, err := s.buildUserFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.userRepo.GetAllPaginated(*filter, pagination.Offset, pagination.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := make([]dto.AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, *userToAdminResponse(&user))
+	}
+
+	response := utils.BuildPaginationResponse(userResponses, total, pagination)
+	return &response, nil
+}
+
+// GetUserDetail returns a user with their full ticket history and what
+// they spent in total, net of refunds.
+func (s *userService) GetUserDetail(userID uint) (*dto.AdminUserDetailResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	tickets, err := s.ticketService.GetUserTickets(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tickets == nil {
+		tickets = []dto.TicketResponse{}
+	}
+
+	totalSpend, err := s.reportRepo.GetUserTotalSpend(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AdminUserDetailResponse{
+		AdminUserResponse: *userToAdminResponse(user),
+		TicketCount:       len(tickets),
+		TotalSpend:        totalSpend,
+		Tickets:           tickets,
+	}, nil
+}
+
+// UpdateRole promotes or demotes a user. The change applies on the user's
+// next request, since the role is read from the database on every request.
+// Admins cannot change their own role, so the last admin cannot lock
+// everyone out.
+func (s *userService) UpdateRole(adminID uint, userID uint, req dto.UpdateUserRoleRequest) (*dto.AdminUserResponse, error) {
+	if _, err := s.roleRepo.GetByName(req.Role); err != nil {
+		return nil, errors.New("invalid role")
+	}
+	if adminID == userID {
+		return nil, errors.New("you cannot change your own role")
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.AnonymizedAt != nil {
+		return nil, errors.New("user account has been deleted")
+	}
+
+	if err := s.userRepo.UpdateRole(user.ID, req.Role); err != nil {
+		return nil, err
+	}
+	user.Role = req.Role
+
+	return userToAdminResponse(user), nil
+}
+
+// Suspend blocks a user right away: their access tokens are rejected on
+// the next request and their refresh tokens are revoked.
+func (s *userService) Suspend(adminID uint, userID uint, req dto.SuspendUserRequest) (*dto.AdminUserResponse, error) {
+	if adminID == userID {
+		return nil, errors.New("you cannot suspend your own account")
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.SuspendedAt != nil {
+		return nil, errors.New("user is already suspended")
+	}
+
+	now := time.Now()
+	reason := strings.TrimSpace(req.Reason)
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.userRepo.WithTx(tx).UpdateSuspension(user.ID, &now, reason); err != nil {
+			return err
+		}
+		return s.refreshRepo.WithTx(tx).RevokeAllForUser(user.ID, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	user.SuspendedAt = &now
+	user.SuspensionReason = reason
+
+	return userToAdminResponse(user), nil
+}
+
+// Unsuspend lifts a suspension. The user has to log in again, since their
+// refresh tokens were revoked on suspension.
+func (s *userService) Unsuspend(userID uint) (*dto.AdminUserResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if user.SuspendedAt == nil {
+		return nil, errors.New("user is not suspended")
+	}
+
+	if err := s.userRepo.UpdateSuspension(user.ID, nil, ""); err != nil {
+		return nil, err
+	}
+	user.SuspendedAt = nil
+	user.SuspensionReason = ""
+
+	return userToAdminResponse(user), nil
+}
+
+func (s *userService) buildUserFilter(query dto.UserListQuery) (*repositories.UserFilter, error) {
+	filter := repositories.UserFilter{
+		Search: strings.TrimSpace(query.Search),
+	}
+
+	if query.Role != "" {
+		if _, err := s.roleRepo.GetByName(query.Role); err != nil {
+			return nil, errors.New("invalid role")
+		}
+		filter.Role = query.Role
+	}
+
+	if query.Status != "" {
+		if query.Status != constants.UserStatusActive && query.Status != constants.UserStatusSuspended {
+			return nil, errors.New("status must be active or suspended")
+		}
+		filter.Status = query.Status
+	}
+
+	if query.RegisteredFrom != "" {
+		registeredFrom, err := parseFilterDate(query.RegisteredFrom, false)
+		if err != nil {
+			return nil, errors.New("invalid registered_from format")
+		}
+		filter.RegisteredFrom = &registeredFrom
+	}
+	if query.RegisteredTo != "" {
+		registeredTo, err := parseFilterDate(query.RegisteredTo, true)
+		if err != nil {
+			return nil, errors.New("invalid registered_to format")
+		}
+		filter.RegisteredTo = &registeredTo
+	}
+	if filter.RegisteredFrom != nil && filter.RegisteredTo != nil && filter.RegisteredTo.Before(*filter.RegisteredFrom) {
+		return nil, errors.New("registered_to must be after registered_from")
+	}
+
+	return &filter, nil
+}
+
+func userToAdminResponse(user *entities.User) *dto.AdminUserResponse {
+	response := &dto.AdminUserResponse{
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Phone:            user.Phone,
+		Role:             user.Role,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		Status:           constants.UserStatusActive,
+		SuspensionReason: user.SuspensionReason,
+		Deleted:          user.AnonymizedAt != nil,
+		CreatedAt:        user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if user.SuspendedAt != nil {
+		suspendedAt := user.SuspendedAt.Format("2006-01-02T15:04:05Z")
+		response.Status = constants.UserStatusSuspended
+		response.SuspendedAt = &suspendedAt
+	}
+	return response
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+	"time"
+	// Venue timezones must resolve even where the host has no zoneinfo
+	_ "time/tzdata"
+
+	"gorm.io/gorm"
+)
+
+type VenueService interface {
+	GetAll() ([]dto.VenueResponse, error)
+	GetByID(id uint) (*dto.VenueResponse, error)
+	Create(req dto.CreateVenueRequest) (*dto.VenueResponse, error)
+	Update(id uint, req dto.UpdateVenueRequest) (*dto.VenueResponse, error)
+	Delete(id uint) error
+	CreateHall(venueID uint, req dto.CreateVenueHallRequest) (*dto.VenueHallResponse, error)
+	UpdateHall(venueID uint, hallID uint, req dto.UpdateVenueHallRequest) (*dto.VenueHallResponse, error)
+	DeleteHall(venueID uint, hallID uint) error
+}
+
+type venueService struct {
+	txManager repositories.TxManager
+	venueRepo repositories.VenueRepository
+}
+
+func NewVenueService(txManager repositories.TxManager, venueRepo repositories.VenueRepository) VenueService {
+	return &venueService{
+		txManager: txManager,
+		venueRepo: venueRepo,
+	}
+}
+
+func (s *venueService) GetAll() ([]dto.VenueResponse, error) {
+	venues, err := s.venueRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	venueResponses := make([]dto.VenueResponse, 0, len(venues))
+	for _, venue := range venues {
+		venueResponses = append(venueResponses, s.entityToResponse(venue))
+	}
+
+	return venueResponses, nil
+}
+
+func (s *venueService) GetByID(id uint) (*dto.VenueResponse, error) {
+	venue, err := s.venueRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("venue not found")
+	}
+
+	response := s.entityToResponse(*venue)
+	return &response, nil
+}
+
+func (s *venueService) Create(req dto.CreateVenueRequest) (*dto.VenueResponse, error) {
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return nil, errors.New("invalid timezone")
+	}
+
+	venue := entities.Venue{
+		Name:        req.Name,
+		Address:     req.Address,
+		City:        req.City,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Timezone:    req.Timezone,
+		MaxCapacity: req.MaxCapacity,
+	}
+
+	names := make(map[string]bool, len(req.Halls))
+	for _, hallReq := range req.Halls {
+		if names[hallReq.Name] {
+			return nil, fmt.Errorf("duplicate hall %q", hallReq.Name)
+		}
+		names[hallReq.Name] = true
+
+		if hallReq.Capacity > req.MaxCapacity {
+			return nil, fmt.Errorf("hall %q capacity exceeds the venue capacity of %d", hallReq.Name, req.MaxCapacity)
+		}
+		venue.Halls = append(venue.Halls, entities.VenueHall{
+			Name:     hallReq.Name,
+			Capacity: hallReq.Capacity,
+		})
+	}
+
+	if err := s.venueRepo.Create(&venue); err != nil {
+		return nil, err
+	}
+
+	response := s.entityToResponse(venue)
+	return &response, nil
+}
+
+func (s *venueService) Update(id uint, req dto.UpdateVenueRequest) (*dto.VenueResponse, error) {
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		venueRepo := s.venueRepo.WithTx(tx)
+
+		venue, err := venueRepo.GetByIDForUpdate(id)
+		if err != nil {
+			return errors.New("venue not found")
+		}
+
+		// Update fields if provided
+		if req.Name != "" {
+			venue.Name = req.Name
+		}
+		if req.Address != "" {
+			venue.Address = req.Address
+		}
+		if req.City != "" {
+			venue.City = req.City
+		}
+		if req.Latitude != nil {
+			venue.Latitude = req.Latitude
+		}
+		if req.Longitude != nil {
+			venue.Longitude = req.Longitude
+		}
+		if req.Timezone != "" {
+			if _, err := time.LoadLocation(req.Timezone); err != nil {
+				return errors.New("invalid timezone")
+			}
+			venue.Timezone = req.Timezone
+		}
+		if req.MaxCapacity > 0 {
+			if err := s.checkCapacityReduction(venueRepo, venue.ID, nil, req.MaxCapacity); err != nil {
+				return err
+			}
+
+			largestHall, err := venueRepo.MaxHallCapacity(venue.ID, 0)
+			if err != nil {
+				return err
+			}
+			if req.MaxCapacity < largestHall {
+				return fmt.Errorf("capacity cannot be lower than the largest hall capacity of %d", largestHall)
+			}
+			venue.MaxCapacity = req.MaxCapacity
+		}
+
+		return venueRepo.Update(venue)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(id)
+}
+
+func (s *venueService) Delete(id uint) error {
+	venue, err := s.venueRepo.GetByID(id)
+	if err != nil {
+		return errors.New("venue not found")
+	}
+
+	count, err := s.venueRepo.CountEvents(venue.ID, nil)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("cannot delete a venue used by %d events", count)
+	}
+
+	return s.venueRepo.Delete(venue)
+}
+
+func (s *venueService) CreateHall(venueID uint, req dto.CreateVenueHallRequest) (*dto.VenueHallResponse, error) {
+	venue, err := s.venueRepo.GetByID(venueID)
+	if err != nil {
+		return nil, errors.New("venue not found")
+	}
+
+	if req.Capacity > venue.MaxCapacity {
+		return nil, fmt.Errorf("hall capacity exceeds the venue capacity of %d", venue.MaxCapacity)
+	}
+	for _, hall := range venue.Halls {
+		if hall.Name == req.Name {
+			return nil, errors.New("venue already has a hall with this name")
+		}
+	}
+
+	hall := entities.VenueHall{
+		VenueID:  venue.ID,
+		Name:     req.Name,
+		Capacity: req.Capacity,
+	}
+	if err := s.venueRepo.CreateHall(&hall); err != nil {
+		return nil, err
+	}
+
+	response := s.hallToResponse(hall)
+	return &response, nil
+}
+
+func (s *venueService) UpdateHall(venueID uint, hallID uint, req dto.UpdateVenueHallRequest) (*dto.VenueHallResponse, error) {
+	var hall *entities.VenueHall
+
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		venueRepo := s.venueRepo.WithTx(tx)
+
+		venue, err := venueRepo.GetByIDForUpdate(venueID)
+		if err != nil {
+			return errors.New("venue not found")
+		}
+
+		hall, err = venueRepo.GetHallByID(hallID)
+		if err != nil || hall.VenueID != venue.ID {
+			return errors.New("hall not found")
+		}
+
+		if req.Name != "" {
+			hall.Name = req.Name
+		}
+		if req.Capacity > 0 {
+			if req.Capacity > venue.MaxCapacity {
+				return fmt.Errorf("hall capacity exceeds the venue capacity of %d", venue.MaxCapacity)
+			}
+			if err := s.checkCapacityReduction(venueRepo, venue.ID, &hall.ID, req.Capacity); err != nil {
+				return err
+			}
+			hall.Capacity = req.Capacity
+		}
+
+		return venueRepo.UpdateHall(hall)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.hallToResponse(*hall)
+	return &response, nil
+}
+
+func (s *venueService) DeleteHall(venueID uint, hallID uint) error {
+	hall, err := s.venueRepo.GetHallByID(hallID)
+	if err != nil || hall.VenueID != venueID {
+		return errors.New("hall not found")
+	}
+
+	count, err := s.venueRepo.CountEvents(venueID, &hall.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("cannot delete a hall used by %d events", count)
+	}
+
+	return s.venueRepo.DeleteHall(hall)
+}
+
+// checkCapacityReduction makes sure a venue or hall never shrinks below an
+// event still scheduled there.
+func (s *venueService) checkCapacityReduction(venueRepo repositories.VenueRepository, venueID uint, hallID *uint, capacity int) error {
+	largestEvent, err := venueRepo.MaxActiveEventCapacity(venueID, hallID)
+	if err != nil {
+		return err
+	}
+	if capacity < largestEvent {
+		return fmt.Errorf("capacity cannot be lower than the %d seats of an event scheduled there", largestEvent)
+	}
+	return nil
+}
+
+// checkEventVenue validates the venue and hall of an event: the hall must
+// belong to the venue, the event must fit in it, and nothing else may be
+// scheduled there at the same time. The venue row stays locked until the
+// transaction ends, so concurrent bookings of the venue are serialized.
+func checkEventVenue(venueRepo repositories.VenueRepository, eventRepo repositories.EventRepository, event *entities.Event) (*entities.Venue, error) {
+	venue, err := venueRepo.GetByIDForUpdate(*event.VenueID)
+	if err != nil {
+		return nil, errors.New("venue not found")
+	}
+
+	limit, place := venue.MaxCapacity, "venue"
+	if event.HallID != nil {
+		hall, err := venueRepo.GetHallByID(*event.HallID)
+		if err != nil || hall.VenueID != venue.ID {
+			return nil, errors.New("hall not found at this venue")
+		}
+		limit, place = hall.Capacity, "hall"
+	}
+
+	if event.Capacity > limit {
+		return nil, fmt.Errorf("event capacity exceeds the %s capacity of %d", place, limit)
+	}
+
+	end := event.EndDate
+	if end.Before(event.Date) {
+		end = event.Date
+	}
+
+	conflict, err := eventRepo.FindVenueConflict(venue.ID, event.HallID, event.Date, end, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	if conflict != nil {
+		return nil, fmt.Errorf("the %s is already booked for %q at that time", place, conflict.Title)
+	}
+
+	return venue, nil
+}
+
+func (s *venueService) entityToResponse(venue entities.Venue) dto.VenueResponse {
+	halls := make([]dto.VenueHallResponse, 0, len(venue.Halls))
+	for _, hall := range venue.Halls {
+		halls = append(halls, s.hallToResponse(hall))
+	}
+
+	return dto.VenueResponse{
+		ID:          venue.ID,
+		Name:        venue.Name,
+		Address:     venue.Address,
+		City:        venue.City,
+		Latitude:    venue.Latitude,
+		Longitude:   venue.Longitude,
+		Timezone:    venue.Timezone,
+		MaxCapacity: venue.MaxCapacity,
+		Halls:       halls,
+	}
+}
+
+func (s *venueService) hallToResponse(hall entities.VenueHall) dto.VenueHallResponse {
+	return dto.VenueHallResponse{
+		ID:       hall.ID,
+		VenueID:  hall.VenueID,
+		Name:     hall.Name,
+		Capacity: hall.Capacity,
+	}
+}
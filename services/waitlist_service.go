@@ -0,0 +1,215 @@
+package services
+
+import (
+	"case_study_api/constants"
+	"case_study_api/dto"
+	"case_study_api/entities"
+	"case_study_api/repositories"
+	"errors"
+	"fmt"
+)
+
+type WaitlistService interface {
+	Join(eventID uint, userID uint, req dto.JoinWaitlistRequest) (*dto.WaitlistEntryResponse, error)
+	GetUserEntries(userID uint) ([]dto.WaitlistEntryResponse, error)
+	Leave(entryID uint, userID uint) error
+	GetEventDemand(eventID uint) (*dto.WaitlistDemandResponse, error)
+}
+
+type waitlistService struct {
+	waitlistRepo repositories.WaitlistRepository
+	eventRepo    repositories.EventRepository
+	tierRepo     repositories.TicketTierRepository
+}
+
+func NewWaitlistService(waitlistRepo repositories.WaitlistRepository, eventRepo repositories.EventRepository, tierRepo repositories.TicketTierRepository) WaitlistService {
+	return &waitlistService{
+		waitlistRepo: waitlistRepo,
+		eventRepo:    eventRepo,
+		tierRepo:     tierRepo,
+	}
+}
+
+func (s *waitlistService) Join(eventID uint, userID uint, req dto.JoinWaitlistRequest) (*dto.WaitlistEntryResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	if !event.IsActive || event.Status == constants.EventStatusCancelled || event.Status == constants.EventStatusCompleted {
+		return nil, errors.New("event is not available")
+	}
+
+	// Only queue people when the request could not be booked right now
+	available := event.Capacity - event.SoldTickets
+	if req.TierID != nil {
+		tier, err := s.tierRepo.GetByID(*req.TierID)
+		if err != nil || tier.EventID != event.ID {
+			return nil, errors.New("tier not found")
+		}
+		if tier.Capacity-tier.Sold < available {
+			available = tier.Capacity - tier.Sold
+		}
+	}
+	if req.Quantity <= available {
+		return nil, fmt.Errorf("%d tickets are still available, book them directly", available)
+	}
+
+	exists, err := s.waitlistRepo.ExistsActive(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("already on the waitlist for this event")
+	}
+
+	entry := entities.WaitlistEntry{
+		EventID:  event.ID,
+		UserID:   userID,
+		TierID:   req.TierID,
+		Quantity: req.Quantity,
+		Status:   constants.WaitlistStatusWaiting,
+	}
+	if err := s.waitlistRepo.Create(&entry); err != nil {
+		return nil, err
+	}
+
+	entry.Event = *event
+	return s.entityToResponse(entry)
+}
+
+func (s *waitlistService) GetUserEntries(userID uint) ([]dto.WaitlistEntryResponse, error) {
+	entries, err := s.waitlistRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entryResponses := make([]dto.WaitlistEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response, err := s.entityToResponse(entry)
+		if err != nil {
+			return nil, err
+		}
+		entryResponses = append(entryResponses, *response)
+	}
+
+	return entryResponses, nil
+}
+
+func (s *waitlistService) Leave(entryID uint, userID uint) error {
+	entry, err := s.waitlistRepo.GetByID(entryID)
+	if err != nil {
+		return errors.New("waitlist entry not found")
+	}
+
+	if entry.UserID != userID {
+		return errors.New("unauthorized access")
+	}
+
+	// Offers are settled through their hold (confirm or release)
+	if entry.Status != constants.WaitlistStatusWaiting {
+		return fmt.Errorf("waitlist entry is already %s", entry.Status)
+	}
+
+	entry.Status = constants.WaitlistStatusCancelled
+	return s.waitlistRepo.Update(entry)
+}
+
+func (s *waitlistService) GetEventDemand(eventID uint) (*dto.WaitlistDemandResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, errors.New("event not found")
+	}
+
+	demand, err := s.waitlistRepo.GetDemandByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.waitlistRepo.GetByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.WaitlistDemandResponse{
+		EventID:     event.ID,
+		Title:       event.Title,
+		Capacity:    event.Capacity,
+		SoldTickets: event.SoldTickets,
+		Entries:     make([]dto.WaitlistEntryResponse, 0, len(entries)),
+	}
+
+	for _, row := range demand {
+		switch row.Status {
+		case constants.WaitlistStatusWaiting:
+			response.WaitingEntries = row.Entries
+			response.WaitingQuantity = row.Quantity
+		case constants.WaitlistStatusOffered:
+			response.OfferedEntries = row.Entries
+			response.OfferedQuantity = row.Quantity
+		case constants.WaitlistStatusFulfilled:
+			response.FulfilledEntries = row.Entries
+		case constants.WaitlistStatusExpired:
+			response.ExpiredEntries = row.Entries
+		case constants.WaitlistStatusDeclined:
+			response.DeclinedEntries = row.Entries
+		}
+	}
+
+	var position int64
+	for _, entry := range entries {
+		entryResponse := s.baseResponse(entry)
+		if entry.Status == constants.WaitlistStatusWaiting {
+			position++
+			entryResponse.Position = position
+		}
+		response.Entries = append(response.Entries, entryResponse)
+	}
+
+	return &response, nil
+}
+
+func (s *waitlistService) entityToResponse(entry entities.WaitlistEntry) (*dto.WaitlistEntryResponse, error) {
+	response := s.baseResponse(entry)
+
+	if entry.Status == constants.WaitlistStatusWaiting {
+		position, err := s.waitlistRepo.GetPosition(&entry)
+		if err != nil {
+			return nil, err
+		}
+		response.Position = position
+	}
+
+	// Expose the hold token so the user can confirm the offer
+	if entry.Status == constants.WaitlistStatusOffered && entry.Hold != nil {
+		response.Hold = &dto.TicketHoldResponse{
+			Token:     entry.Hold.Token,
+			EventID:   entry.Hold.EventID,
+			Quantity:  entry.Hold.Quantity,
+			Status:    entry.Hold.Status,
+			ExpiresAt: entry.Hold.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+			TierID:    entry.Hold.TierID,
+		}
+	}
+
+	return &response, nil
+}
+
+func (s *waitlistService) baseResponse(entry entities.WaitlistEntry) dto.WaitlistEntryResponse {
+	response := dto.WaitlistEntryResponse{
+		ID:         entry.ID,
+		EventID:    entry.EventID,
+		EventTitle: entry.Event.Title,
+		TierID:     entry.TierID,
+		Quantity:   entry.Quantity,
+		Status:     entry.Status,
+		JoinedAt:   entry.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if entry.OfferedAt != nil {
+		offeredAt := entry.OfferedAt.Format("2006-01-02T15:04:05Z")
+		response.OfferedAt = &offeredAt
+	}
+
+	return response
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "time"
+
+// Clock tells the current time. Services that act on schedules take a Clock
+// instead of calling time.Now so the time can be controlled from outside.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by the machine time.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// icalTimeFormat is the UTC DATE-TIME form of RFC 5545.
+const icalTimeFormat = "20060102T150405Z"
+
+// ICalEvent is a single VEVENT. Events with a zero End have no DTEND.
+type ICalEvent struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	Cancelled    bool
+	LastModified time.Time
+}
+
+// BuildICalendar renders events as an RFC 5545 calendar named name. Calendar
+// apps match events by UID, so a re-fetched event that became cancelled
+// replaces the one they already show.
+func BuildICalendar(name string, events []ICalEvent, now time.Time) []byte {
+	var b strings.Builder
+
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//Malaka Ticket//Event Ticket API//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(name))
+
+	for _, event := range events {
+		status := "CONFIRMED"
+		if event.Cancelled {
+			status = "CANCELLED"
+		}
+
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+now.UTC().Format(icalTimeFormat))
+		writeICalLine(&b, "DTSTART:"+event.Start.UTC().Format(icalTimeFormat))
+		if !event.End.IsZero() {
+			writeICalLine(&b, "DTEND:"+event.End.UTC().Format(icalTimeFormat))
+		}
+		if !event.LastModified.IsZero() {
+			writeICalLine(&b, "LAST-MODIFIED:"+event.LastModified.UTC().Format(icalTimeFormat))
+		}
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			writeICalLine(&b, "LOCATION:"+escapeICalText(event.Location))
+		}
+		writeICalLine(&b, "STATUS:"+status)
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeICalText escapes a TEXT value as required by RFC 5545 section 3.3.11.
+func escapeICalText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeICalLine writes a content line, folding it so that no line is longer
+// than 75 octets without splitting a UTF-8 character.
+func writeICalLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"case_study_api/config"
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -13,12 +14,20 @@ type JWTClaim struct {
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(userID uint, role string) (string, error) {
+// GenerateJWT issues an access token valid for ttl. Every token carries a
+// random ID (jti) so it can be revoked before it expires.
+func GenerateJWT(userID uint, role string, ttl time.Duration) (string, error) {
+	jti, err := GenerateSecureToken(16)
+	if err != nil {
+		return "", err
+	}
+
 	claims := JWTClaim{
 		UserID: userID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(72 * time.Hour)),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -26,3 +35,20 @@ func GenerateJWT(userID uint, role string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(config.App.JWTSecret))
 }
+
+// ParseJWT verifies an access token and returns its claims. Tokens without
+// a jti predate revocation support and are rejected.
+func ParseJWT(tokenStr string) (*JWTClaim, error) {
+	claims := &JWTClaim{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(config.App.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid or expired JWT")
+	}
+
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, errors.New("invalid JWT claims")
+	}
+	return claims, nil
+}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"math"
 	"strconv"
 
@@ -11,6 +14,10 @@ type PaginationRequest struct {
 	Page     int `json:"page"`
 	PageSize int `json:"page_size"`
 	Offset   int `json:"-"`
+	// UseCursor is set when the client asked for cursor pagination by
+	// sending a cursor parameter; an empty cursor requests the first page
+	UseCursor bool   `json:"-"`
+	Cursor    string `json:"-"`
 }
 
 type PaginationResponse struct {
@@ -21,6 +28,23 @@ type PaginationResponse struct {
 	Data       interface{} `json:"data"`
 }
 
+type CursorPaginationResponse struct {
+	PageSize   int         `json:"page_size"`
+	NextCursor *string     `json:"next_cursor"`
+	PrevCursor *string     `json:"prev_cursor"`
+	Data       interface{} `json:"data"`
+}
+
+// Cursor is the decoded form of an opaque pagination cursor. It points at
+// the row a page starts after, identified by its sort value and ID.
+type Cursor struct {
+	Sort     string `json:"s,omitempty"`
+	Order    string `json:"o,omitempty"`
+	Value    string `json:"v,omitempty"`
+	ID       uint   `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+}
+
 type PaginationMeta struct {
 	Page       int   `json:"page"`
 	PageSize   int   `json:"page_size"`
@@ -46,11 +70,49 @@ func GetPaginationFromQuery(c *gin.Context) PaginationRequest {
 	}
 
 	offset := (page - 1) * pageSize
+	cursor, useCursor := c.GetQuery("cursor")
 
 	return PaginationRequest{
-		Page:     page,
-		PageSize: pageSize,
-		Offset:   offset,
+		Page:      page,
+		PageSize:  pageSize,
+		Offset:    offset,
+		UseCursor: useCursor,
+		Cursor:    cursor,
+	}
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor. An empty string
+// decodes to nil, meaning the first page.
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// BuildCursorResponse creates a cursor paginated response
+func BuildCursorResponse(data interface{}, pageSize int, nextCursor, prevCursor *string) CursorPaginationResponse {
+	return CursorPaginationResponse{
+		PageSize:   pageSize,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Data:       data,
 	}
 }
 
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+)
+
+// bookingCodeAlphabet leaves out characters that are easy to misread at the
+// door (0/O, 1/I/L).
+const bookingCodeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// GenerateSecureToken returns a random hex string built from n bytes of
+// crypto/rand entropy.
+func GenerateSecureToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashToken returns the SHA-256 of a secret token as hex. Only the hash is
+// stored, so a leaked database does not expose working tokens.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateBookingCode returns an unguessable booking code such as
+// BK7KQ2MZ9XWP4D. Uniqueness must still be checked against the database.
+func GenerateBookingCode() (string, error) {
+	alphabetSize := big.NewInt(int64(len(bookingCodeAlphabet)))
+
+	code := make([]byte, 12)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = bookingCodeAlphabet[n.Int64()]
+	}
+	return "BK" + string(code), nil
+}
@@ -0,0 +1,47 @@
+package workers
+
+import (
+	"case_study_api/services"
+	"context"
+	"log"
+	"time"
+)
+
+// EventCancellationWorker picks up event cancellation jobs that did not
+// finish, such as jobs interrupted by a restart or a failed batch.
+type EventCancellationWorker struct {
+	cancellationService services.EventCancellationService
+	interval            time.Duration
+}
+
+func NewEventCancellationWorker(cancellationService services.EventCancellationService, interval time.Duration) *EventCancellationWorker {
+	return &EventCancellationWorker{
+		cancellationService: cancellationService,
+		interval:            interval,
+	}
+}
+
+// Start runs the worker in the background until ctx is cancelled. Unfinished
+// jobs are resumed right away on startup.
+func (w *EventCancellationWorker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		w.resume()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.resume()
+			}
+		}
+	}()
+}
+
+func (w *EventCancellationWorker) resume() {
+	if err := w.cancellationService.ResumeJobs(); err != nil {
+		log.Printf("Event cancellation worker failed: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package workers
+
+import (
+	"case_study_api/services"
+	"context"
+	"log"
+	"time"
+)
+
+// EventLifecycleScheduler periodically advances event statuses as their
+// start and end times pass.
+type EventLifecycleScheduler struct {
+	lifecycleService services.EventLifecycleService
+	interval         time.Duration
+}
+
+func NewEventLifecycleScheduler(lifecycleService services.EventLifecycleService, interval time.Duration) *EventLifecycleScheduler {
+	return &EventLifecycleScheduler{
+		lifecycleService: lifecycleService,
+		interval:         interval,
+	}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled. The
+// first run happens right away so events that changed state while the
+// server was down are caught up on startup.
+func (w *EventLifecycleScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		w.run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.run()
+			}
+		}
+	}()
+}
+
+func (w *EventLifecycleScheduler) run() {
+	run, err := w.lifecycleService.RunTransitions()
+	if err != nil {
+		log.Printf("Event lifecycle scheduler failed: %v", err)
+		return
+	}
+	if run.EventsStarted > 0 || run.EventsCompleted > 0 {
+		log.Printf("Event lifecycle scheduler started %d and completed %d events", run.EventsStarted, run.EventsCompleted)
+	}
+}
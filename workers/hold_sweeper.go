@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"case_study_api/services"
+	"context"
+	"log"
+	"time"
+)
+
+// HoldSweeper periodically releases expired seat holds so their quantity
+// becomes available to other buyers again.
+type HoldSweeper struct {
+	ticketService services.TicketService
+	interval      time.Duration
+}
+
+func NewHoldSweeper(ticketService services.TicketService, interval time.Duration) *HoldSweeper {
+	return &HoldSweeper{
+		ticketService: ticketService,
+		interval:      interval,
+	}
+}
+
+// Start runs the sweeper in the background until ctx is cancelled.
+func (w *HoldSweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.sweep()
+			}
+		}
+	}()
+}
+
+func (w *HoldSweeper) sweep() {
+	released, err := w.ticketService.ReleaseExpiredHolds()
+	if err != nil {
+		log.Printf("Hold sweeper failed: %v", err)
+	}
+	if released > 0 {
+		log.Printf("Hold sweeper released %d expired holds", released)
+	}
+}
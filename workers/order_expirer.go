@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"case_study_api/services"
+	"context"
+	"log"
+	"time"
+)
+
+// OrderExpirer periodically cancels orders that were never paid so the
+// seats they reserved go back on sale.
+type OrderExpirer struct {
+	ticketService services.TicketService
+	interval      time.Duration
+}
+
+func NewOrderExpirer(ticketService services.TicketService, interval time.Duration) *OrderExpirer {
+	return &OrderExpirer{
+		ticketService: ticketService,
+		interval:      interval,
+	}
+}
+
+// Start runs the expirer in the background until ctx is cancelled.
+func (w *OrderExpirer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.expire()
+			}
+		}
+	}()
+}
+
+func (w *OrderExpirer) expire() {
+	expired, err := w.ticketService.ExpireStaleOrders()
+	if err != nil {
+		log.Printf("Order expirer failed: %v", err)
+	}
+	if expired > 0 {
+		log.Printf("Order expirer cancelled %d unpaid orders", expired)
+	}
+}
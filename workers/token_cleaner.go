@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"case_study_api/services"
+	"context"
+	"log"
+	"time"
+)
+
+// TokenCleaner periodically deletes expired refresh tokens and revocation
+// entries so the auth tables do not grow forever.
+type TokenCleaner struct {
+	authService services.AuthService
+	interval    time.Duration
+}
+
+func NewTokenCleaner(authService services.AuthService, interval time.Duration) *TokenCleaner {
+	return &TokenCleaner{
+		authService: authService,
+		interval:    interval,
+	}
+}
+
+// Start runs the cleaner in the background until ctx is cancelled.
+func (w *TokenCleaner) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.clean()
+			}
+		}
+	}()
+}
+
+func (w *TokenCleaner) clean() {
+	deleted, err := w.authService.PurgeExpiredTokens()
+	if err != nil {
+		log.Printf("Token cleaner failed: %v", err)
+	}
+	if deleted > 0 {
+		log.Printf("Token cleaner deleted %d expired tokens", deleted)
+	}
+}